@@ -1,6 +1,9 @@
 package bare
 
-import "github.com/mycodesmells/pkg-errors-example/common"
+import (
+	"github.com/mycodesmells/pkg-errors-example/common"
+	"github.com/pkg/errors"
+)
 
 func CallA() error {
 	return CallB()
@@ -11,5 +14,36 @@ func CallB() error {
 }
 
 func CallC() error {
-	return common.MyError{Msg: "Error from CallC"}
+	return common.NewError(401, "Error from CallC")
+}
+
+func CallAWithStack() error {
+	return CallBWithStack()
+}
+
+func CallBWithStack() error {
+	return CallCWithStack()
+}
+
+func CallCWithStack() error {
+	return errors.WithStack(CallC())
+}
+
+func CallAAnnotated() error {
+	return annotate(CallBAnnotated(), "CallA")
+}
+
+func CallBAnnotated() error {
+	return annotate(CallCAnnotated(), "CallB")
+}
+
+func CallCAnnotated() error {
+	return annotate(CallC(), "CallC")
+}
+
+func annotate(err error, call string) error {
+	if me, ok := err.(common.MyError); ok {
+		return me.WithField(call, true)
+	}
+	return err
 }
@@ -0,0 +1,48 @@
+package bare_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/mycodesmells/pkg-errors-example/bare"
+	"github.com/mycodesmells/pkg-errors-example/common"
+)
+
+func TestCallAWithStack(t *testing.T) {
+	err := bare.CallAWithStack()
+	var me common.MyError
+	if !errors.As(err, &me) || me.Msg != "Error from CallC" {
+		t.Fatalf("errors.As() = %v, want the MyError from CallC", me)
+	}
+	if err.Error() != bare.CallA().Error() {
+		t.Errorf("Error() = %q, want the bare message %q", err.Error(), bare.CallA().Error())
+	}
+	if verbose := fmt.Sprintf("%+v", err); !strings.Contains(verbose, "bare.CallC") {
+		t.Errorf("%%+v = %q, want a frame for bare.CallC", verbose)
+	}
+}
+
+func TestCallAAnnotated(t *testing.T) {
+	err := bare.CallAAnnotated()
+	if got, want := err.Error(), bare.CallC().Error(); got != want {
+		t.Errorf("Error() = %q, want the root message %q", got, want)
+	}
+	me, ok := err.(common.MyError)
+	if !ok {
+		t.Fatalf("CallAAnnotated() = %T, want a bare common.MyError", err)
+	}
+	fields := me.Fields()
+	for _, call := range []string{"CallA", "CallB", "CallC"} {
+		if fields[call] != true {
+			t.Errorf("Fields()[%q] = %v, want true", call, fields[call])
+		}
+	}
+	if len(fields) != 3 {
+		t.Errorf("Fields() = %v, want exactly CallA, CallB and CallC", fields)
+	}
+	if me.Code != 401 {
+		t.Errorf("Code = %d, want 401", me.Code)
+	}
+}
@@ -0,0 +1,50 @@
+package common
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type canonLayer struct {
+	mine bool
+	code int
+	msg  string
+}
+
+func (l canonLayer) String() string {
+	if l.mine {
+		return fmt.Sprintf("MyError{code=%d,msg=%q}", l.code, l.msg)
+	}
+	return strconv.Quote(l.msg)
+}
+
+// Canonical renders the chain innermost first without stack traces, e.g.
+// MyError{code=404,msg="Error from CallC"} <- "Error from CallB" <- "Error from CallA".
+func Canonical(err error) string {
+	layers := canonicalLayers(err)
+	parts := make([]string, len(layers))
+	for i, l := range layers {
+		parts[i] = l.String()
+	}
+	return strings.Join(parts, " <- ")
+}
+
+func canonicalLayers(err error) []canonLayer {
+	chain := Chain(err)
+	var layers []canonLayer
+	for i := len(chain) - 1; i >= 0; i-- {
+		var cause error
+		if i+1 < len(chain) {
+			cause = chain[i+1]
+		}
+		if me, ok := layer(chain[i]); ok {
+			layers = append(layers, canonLayer{mine: true, code: me.Code, msg: me.Msg})
+			continue
+		}
+		if msg := local(chain[i], cause); msg != "" {
+			layers = append(layers, canonLayer{msg: msg})
+		}
+	}
+	return layers
+}
@@ -0,0 +1,31 @@
+package common_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/mycodesmells/pkg-errors-example/bare"
+	"github.com/mycodesmells/pkg-errors-example/common"
+	"github.com/mycodesmells/pkg-errors-example/concat"
+	"github.com/mycodesmells/pkg-errors-example/wrap"
+)
+
+func TestCanonical(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"wrap", wrap.CallA(), `MyError{code=404,msg="Error from CallC"} <- "Error from CallB" <- "Error from CallA"`},
+		{"concat", concat.CallA(), `MyError{code=403,msg="Error from CallC"} <- "Error from CallB" <- "Error from CallA"`},
+		{"bare", bare.CallA(), `MyError{code=401,msg="Error from CallC"}`},
+		{"foreign root", fmt.Errorf("Error from CallB: %w", errors.New("disk full")), `"disk full" <- "Error from CallB"`},
+		{"nil", nil, ""},
+	}
+	for _, tt := range tests {
+		if got := common.Canonical(tt.err); got != tt.want {
+			t.Errorf("%s: Canonical() = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package common
+
+import "strings"
+
+// Chain returns every error in the chain, outermost first. Each
+// pkg/errors.Wrap call contributes two entries, a stack layer and a message
+// layer, so wrap.CallA() yields five errors rather than one per function.
+//
+// Chain, Walk, Depth and Flatten follow a single line of causes: they stop at
+// an error that only implements Unwrap() []error, such as Join or MultiError,
+// without visiting its children.
+func Chain(err error) []error {
+	var chain []error
+	Walk(err, func(e error) bool {
+		chain = append(chain, e)
+		return true
+	})
+	return chain
+}
+
+// maxDepth bounds every chain walk, so a cyclic chain (e.g. a *MyError whose
+// Cause points back at itself) stops instead of spinning forever.
+const maxDepth = 100
+
+func Walk(err error, fn func(error) bool) {
+	for depth := 0; err != nil && depth < maxDepth; depth++ {
+		if !fn(err) {
+			return
+		}
+		err = next(err)
+	}
+}
+
+func FindFunc(err error, pred func(error) bool) (error, bool) {
+	var found error
+	Walk(err, func(e error) bool {
+		if pred(e) {
+			found = e
+			return false
+		}
+		return true
+	})
+	return found, found != nil
+}
+
+// Depth counts the layers above the innermost error, i.e. len(Chain(err))-1.
+// It counts error values, not calls: wrap.CallA() reports 4 because each
+// pkg/errors.Wrap adds both a stack and a message layer, while
+// concat.CallA() reports 2, one %w wrapper per call.
+func Depth(err error) int {
+	depth := -1
+	Walk(err, func(error) bool {
+		depth++
+		return true
+	})
+	if depth < 0 {
+		return 0
+	}
+	return depth
+}
+
+func next(err error) error {
+	switch e := err.(type) {
+	case *MyError:
+		// A nil *MyError ends the chain rather than panicking in Unwrap.
+		if e == nil {
+			return nil
+		}
+		return e.Cause
+	case interface{ Unwrap() error }:
+		return e.Unwrap()
+	case interface{ Cause() error }:
+		return e.Cause()
+	}
+	return nil
+}
+
+// innermost returns the deepest MyError in the chain. Unlike Walk it descends
+// into the children of an Unwrap() []error error, in order, and the first
+// child holding a MyError wins. At most maxDepth errors are visited in total.
+func innermost(err error) (MyError, bool) {
+	budget := maxDepth
+	return innermostFrom(err, &budget)
+}
+
+func innermostFrom(err error, budget *int) (MyError, bool) {
+	var me MyError
+	found := false
+	for ; err != nil && *budget > 0; err = next(err) {
+		*budget--
+		if l, ok := layer(err); ok {
+			me, found = l, true
+		}
+		if multi, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, child := range multi.Unwrap() {
+				if l, ok := innermostFrom(child, budget); ok {
+					return l, true
+				}
+			}
+			break
+		}
+	}
+	return me, found
+}
+
+func layer(err error) (MyError, bool) {
+	switch e := err.(type) {
+	case MyError:
+		return e, true
+	case *MyError:
+		if e != nil {
+			return *e, true
+		}
+	}
+	return MyError{}, false
+}
+
+func split(err, cause error) (prefix, suffix string, ok bool) {
+	msg, causeMsg := err.Error(), cause.Error()
+	i := strings.LastIndex(msg, causeMsg)
+	if i < 0 {
+		return msg, "", false
+	}
+	return msg[:i], msg[i+len(causeMsg):], true
+}
+
+func local(err, cause error) string {
+	if me, ok := layer(err); ok {
+		return me.message()
+	}
+	if cause == nil {
+		return err.Error()
+	}
+	prefix, suffix, _ := split(err, cause)
+	return trimLocal(prefix, suffix)
+}
+
+func trimLocal(prefix, suffix string) string {
+	return strings.TrimSuffix(prefix, ": ") + suffix
+}
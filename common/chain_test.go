@@ -0,0 +1,154 @@
+package common_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/mycodesmells/pkg-errors-example/bare"
+	"github.com/mycodesmells/pkg-errors-example/common"
+	"github.com/mycodesmells/pkg-errors-example/concat"
+	"github.com/mycodesmells/pkg-errors-example/wrap"
+	pkgerrors "github.com/pkg/errors"
+)
+
+func chainTypes(err error) []string {
+	var types []string
+	for _, e := range common.Chain(err) {
+		types = append(types, fmt.Sprintf("%T", e))
+	}
+	return types
+}
+
+func TestChain(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want []string
+	}{
+		{"wrap", wrap.CallA(), []string{
+			"*errors.withStack",
+			"*errors.withMessage",
+			"*errors.withStack",
+			"*errors.withMessage",
+			"common.MyError",
+		}},
+		{"concat", concat.CallA(), []string{"*fmt.wrapError", "*fmt.wrapError", "common.MyError"}},
+		{"bare", bare.CallA(), []string{"common.MyError"}},
+		{"nil", nil, nil},
+	}
+	for _, tt := range tests {
+		if got := chainTypes(tt.err); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("%s: Chain() types = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestWalk(t *testing.T) {
+	var visited []error
+	common.Walk(wrap.CallA(), func(e error) bool {
+		visited = append(visited, e)
+		return true
+	})
+	if got := len(visited); got != 5 {
+		t.Errorf("Walk(wrap.CallA()) visited %d errors, want 5", got)
+	}
+	if _, ok := visited[len(visited)-1].(common.MyError); !ok {
+		t.Errorf("Walk(wrap.CallA()) ended at %T, want common.MyError", visited[len(visited)-1])
+	}
+
+	visited = nil
+	common.Walk(bare.CallA(), func(e error) bool {
+		visited = append(visited, e)
+		return true
+	})
+	if len(visited) != 1 {
+		t.Errorf("Walk(bare.CallA()) visited %d errors, want 1", len(visited))
+	}
+}
+
+func TestWalkStopsEarly(t *testing.T) {
+	calls := 0
+	var first error
+	common.Walk(wrap.CallA(), func(e error) bool {
+		calls++
+		if _, ok := e.(interface{ StackTrace() pkgerrors.StackTrace }); ok {
+			first = e
+			return false
+		}
+		return true
+	})
+	if calls != 1 || first == nil {
+		t.Errorf("Walk() made %d calls, want to stop after the first stack layer", calls)
+	}
+}
+
+func TestDepth(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"wrap", wrap.CallA(), 4},
+		{"concat", concat.CallA(), 2},
+		{"bare", bare.CallA(), 0},
+		{"nil", nil, 0},
+	}
+	for _, tt := range tests {
+		if got := common.Depth(tt.err); got != tt.want {
+			t.Errorf("%s: Depth() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCyclicChain(t *testing.T) {
+	cy := &common.MyError{Msg: "loop"}
+	cy.Cause = cy
+	if n := len(common.Chain(cy)); n != 100 {
+		t.Errorf("len(Chain(cy)) = %d, want 100", n)
+	}
+	if n := strings.Count(fmt.Sprintf("%+v", cy), "Errrrr: loop"); n != 100 {
+		t.Errorf("%%+v printed %d layers, want 100", n)
+	}
+
+	joined := &common.MyError{Msg: "joined"}
+	joined.Cause = common.Join(joined, joined)
+	// Each join layer uses up one of the 100 as well.
+	if n := strings.Count(fmt.Sprintf("%+v", joined), "Errrrr: joined"); n != 50 {
+		t.Errorf("%%+v of a join cycle printed %d layers, want 50", n)
+	}
+
+	cy.Sensitive = true
+	redacted := common.Redact(cy)
+	if n := len(common.Chain(redacted)); n != 100 {
+		t.Errorf("len(Chain(Redact(cy))) = %d, want 100", n)
+	}
+	if n := strings.Count(fmt.Sprintf("%+v", redacted), "Errrrr: [REDACTED]"); n != 100 {
+		t.Errorf("%%+v of Redact(cy) printed %d layers, want 100", n)
+	}
+}
+
+func TestFindFunc(t *testing.T) {
+	err := wrap.CallA()
+
+	found, ok := common.FindFunc(err, func(e error) bool {
+		me, ok := e.(common.MyError)
+		return ok && me.Code == 404
+	})
+	if me, _ := found.(common.MyError); !ok || me.Msg != "Error from CallC" {
+		t.Errorf("FindFunc(by code) = %v, %v, want the CallC MyError", found, ok)
+	}
+
+	found, ok = common.FindFunc(err, func(e error) bool {
+		_, ok := e.(interface{ StackTrace() pkgerrors.StackTrace })
+		return ok
+	})
+	if !ok || found != err {
+		t.Errorf("FindFunc(by type) = %v, %v, want the outermost stack layer", found, ok)
+	}
+
+	found, ok = common.FindFunc(err, func(e error) bool { return false })
+	if found != nil || ok {
+		t.Errorf("FindFunc(no match) = %v, %v, want nil, false", found, ok)
+	}
+}
@@ -0,0 +1,34 @@
+package common
+
+import "fmt"
+
+func NewError(code int, msg string) MyError {
+	return created(MyError{Code: code, Msg: msg})
+}
+
+// CodeOf returns the code of the innermost MyError in the chain, i.e. the one
+// closest to where the error originated. For joined errors the first branch
+// holding a MyError is used.
+//
+// The bare, concat and wrap demo packages only stamp a code in CallC: giving
+// CallA and CallB their own codes would need an extra MyError or WrapCode
+// layer and change the chain shape each package exists to demonstrate.
+func CodeOf(err error) (int, bool) {
+	me, ok := innermost(err)
+	if !ok {
+		return 0, false
+	}
+	return me.Code, true
+}
+
+type codeTarget int
+
+func (c codeTarget) Error() string {
+	return fmt.Sprintf("error code %d", int(c))
+}
+
+// WithCode returns a target for errors.Is that matches any MyError with the
+// given code.
+func WithCode(code int) error {
+	return codeTarget(code)
+}
@@ -0,0 +1,56 @@
+package common_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mycodesmells/pkg-errors-example/bare"
+	"github.com/mycodesmells/pkg-errors-example/common"
+	"github.com/mycodesmells/pkg-errors-example/concat"
+	"github.com/mycodesmells/pkg-errors-example/wrap"
+	pkgerrors "github.com/pkg/errors"
+)
+
+func TestCodeOf(t *testing.T) {
+	stamped := common.MyError{
+		Code: 500,
+		Msg:  "Error from CallA",
+		Cause: pkgerrors.Wrap(common.MyError{
+			Code:  502,
+			Msg:   "Error from CallB",
+			Cause: common.NewError(404, "Error from CallC"),
+		}, "wrapped"),
+	}
+	tests := []struct {
+		name   string
+		err    error
+		want   int
+		wantOK bool
+	}{
+		{"bare", bare.CallA(), 401, true},
+		{"concat", concat.CallA(), 403, true},
+		{"wrap", wrap.CallA(), 404, true},
+		{"stamped at every level", stamped, 404, true},
+		{"plain", errors.New("plain"), 0, false},
+		{"nil", nil, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := common.CodeOf(tt.err)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("%s: CodeOf() = %d, %v, want %d, %v", tt.name, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestWithCode(t *testing.T) {
+	err := wrap.CallA()
+	if !errors.Is(err, common.WithCode(404)) {
+		t.Error("errors.Is(wrap.CallA(), WithCode(404)) = false, want true")
+	}
+	if errors.Is(err, common.WithCode(500)) {
+		t.Error("errors.Is(wrap.CallA(), WithCode(500)) = true, want false")
+	}
+	if errors.Is(errors.New("Error from CallC"), common.WithCode(0)) {
+		t.Error("WithCode(0) matched an error without a MyError")
+	}
+}
@@ -1,11 +1,92 @@
 package common
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"io"
+)
 
+// MyError deliberately has no Cause() method: the name is taken by the Cause
+// field, and a root returning itself would make errors.Cause loop forever.
+// Since MyError is not a causer, errors.Cause already stops on it.
 type MyError struct {
-	Msg string
+	Code      int
+	Msg       string
+	Cause     error
+	fields    *fieldSet
+	Level     Severity
+	Retryable bool
+	TimedOut  bool
+	Canceled  bool
+	Sensitive bool
+
+	OriginFunc string
+	OriginLine int
+
+	MessageKey string
+	args       *argList
 }
 
 func (me MyError) Error() string {
-	return fmt.Sprintf("Errrrr: %s", me.Msg)
+	return truncate(fmt.Sprintf("Errrrr: %s", me.message()))
+}
+
+func (me MyError) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			writeVerbose(s, me)
+			return
+		}
+		fallthrough
+	case 's':
+		io.WriteString(s, me.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", me.Error())
+	}
+}
+
+func (me MyError) Unwrap() error {
+	return me.Cause
+}
+
+func (me MyError) WithCause(err error) MyError {
+	me.Cause = err
+	return me
+}
+
+func (me MyError) Is(target error) bool {
+	switch t := target.(type) {
+	case MyError:
+		return me.Msg == t.Msg
+	case *MyError:
+		return t != nil && me.Msg == t.Msg
+	case codeTarget:
+		return me.Code == int(t)
+	}
+	return false
+}
+
+func AsMyError(err error) (MyError, bool) {
+	var me MyError
+	if errors.As(err, &me) {
+		return me, true
+	}
+	var pme *MyError
+	if errors.As(err, &pme) && pme != nil {
+		return *pme, true
+	}
+	return MyError{}, false
+}
+
+func SameError(a, b error) bool {
+	ma, ok := innermost(a)
+	if !ok {
+		return false
+	}
+	mb, ok := innermost(b)
+	if !ok {
+		return false
+	}
+	return ma.Msg == mb.Msg && (ma.Code == 0 || mb.Code == 0 || ma.Code == mb.Code)
 }
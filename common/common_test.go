@@ -0,0 +1,161 @@
+package common_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/mycodesmells/pkg-errors-example/bare"
+	"github.com/mycodesmells/pkg-errors-example/common"
+	"github.com/mycodesmells/pkg-errors-example/concat"
+	"github.com/mycodesmells/pkg-errors-example/wrap"
+	pkgerrors "github.com/pkg/errors"
+)
+
+func TestIs(t *testing.T) {
+	if !errors.Is(wrap.CallA(), common.MyError{Msg: "Error from CallC"}) {
+		t.Error("errors.Is(wrap.CallA(), MyError{Error from CallC}) = false, want true")
+	}
+	if !errors.Is(wrap.CallA(), &common.MyError{Msg: "Error from CallC"}) {
+		t.Error("errors.Is with a *MyError target = false, want true")
+	}
+	if errors.Is(wrap.CallA(), common.MyError{Msg: "Error from CallB"}) {
+		t.Error("errors.Is matched a MyError with a different Msg")
+	}
+}
+
+func TestAsMyError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"pkg/errors", wrap.CallA()},
+		{"fmt", fmt.Errorf("outer: %w", common.MyError{Msg: "Error from CallC"})},
+		{"pointer", fmt.Errorf("outer: %w", &common.MyError{Msg: "Error from CallC"})},
+	}
+	for _, tt := range tests {
+		me, ok := common.AsMyError(tt.err)
+		if !ok || me.Msg != "Error from CallC" {
+			t.Errorf("%s: AsMyError() = %v, %v, want Error from CallC, true", tt.name, me, ok)
+		}
+	}
+	if _, ok := common.AsMyError(errors.New("plain")); ok {
+		t.Error("AsMyError(plain) found a MyError")
+	}
+}
+
+func TestFormat(t *testing.T) {
+	err := common.MyError{Msg: "outer", Cause: common.MyError{Msg: "inner", Cause: errors.New("root")}}
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"%v", "Errrrr: outer"},
+		{"%s", "Errrrr: outer"},
+		{"%q", `"Errrrr: outer"`},
+		{"%+v", "Errrrr: outer\nErrrrr: inner\nroot"},
+	}
+	for _, tt := range tests {
+		if got := fmt.Sprintf(tt.format, err); got != tt.want {
+			t.Errorf("Sprintf(%q) = %q, want %q", tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestFormatVerbosePackages(t *testing.T) {
+	if got, want := fmt.Sprintf("%+v", bare.CallA()), "Errrrr: Error from CallC"; got != want {
+		t.Errorf("bare: %%+v = %q, want %q", got, want)
+	}
+	if got, want := fmt.Sprintf("%+v", concat.CallA()), "Error from CallA: Error from CallB: Errrrr: Error from CallC"; got != want {
+		t.Errorf("concat: %%+v = %q, want %q", got, want)
+	}
+	got := fmt.Sprintf("%+v", wrap.CallA())
+	want := "Errrrr: Error from CallC\nError from CallB\n"
+	if !strings.HasPrefix(got, want) || !strings.Contains(got, "\nError from CallA\n") || !strings.Contains(got, "wrap.CallB") {
+		t.Errorf("wrap: %%+v = %q, want message lines followed by stack frames", got)
+	}
+}
+
+func TestSameError(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b error
+		want bool
+	}{
+		{"concat against literal", concat.CallA(), common.MyError{Msg: "Error from CallC"}, true},
+		{"wrap against concat with different codes", wrap.CallA(), concat.CallA(), false},
+		{"code matches", wrap.CallA(), common.NewError(404, "Error from CallC"), true},
+		{"code differs", wrap.CallA(), common.NewError(500, "Error from CallC"), false},
+		{"msg differs", wrap.CallA(), common.MyError{Msg: "Error from CallB"}, false},
+		{"no MyError", errors.New("x"), errors.New("x"), false},
+		{"nil", nil, common.MyError{}, false},
+	}
+	for _, tt := range tests {
+		if got := common.SameError(tt.a, tt.b); got != tt.want {
+			t.Errorf("%s: SameError() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// errors.Cause only follows Cause() methods. It digs through the pkg/errors
+// wrappers of wrap down to the MyError, but stops at the outermost
+// *fmt.wrapError of concat, since %w wrappers only implement Unwrap.
+func TestPkgErrorsCause(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"wrap", wrap.CallA(), "common.MyError"},
+		{"concat", concat.CallA(), "*fmt.wrapError"},
+		{"bare", bare.CallA(), "common.MyError"},
+	}
+	for _, tt := range tests {
+		if got := fmt.Sprintf("%T", pkgerrors.Cause(tt.err)); got != tt.want {
+			t.Errorf("%s: errors.Cause() type = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+	if root := pkgerrors.Cause(common.MyError{Msg: "root"}); root.Error() != "Errrrr: root" {
+		t.Errorf("errors.Cause(MyError) = %v, want the MyError itself", root)
+	}
+}
+
+func TestUnwrap(t *testing.T) {
+	callC := bare.CallC()
+	callB := common.MyError{Msg: "Error from CallB"}.WithCause(callC)
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"no cause", common.MyError{Msg: "root"}, nil},
+		{"MyError at CallB over bare CallC", callB, callC},
+		{"foreign cause", common.MyError{Msg: "outer", Cause: common.ErrNotFound}, common.ErrNotFound},
+	}
+	for _, tt := range tests {
+		if got := errors.Unwrap(tt.err); got != tt.want {
+			t.Errorf("%s: errors.Unwrap() = %#v, want %#v", tt.name, got, tt.want)
+		}
+	}
+	if !errors.Is(callB, common.MyError{Msg: "Error from CallC"}) {
+		t.Error("errors.Is(CallB wrapper, CallC) = false, want true")
+	}
+	if code, _ := common.CodeOf(callB); code != 401 {
+		t.Errorf("CodeOf(CallB wrapper) = %d, want the CallC code 401", code)
+	}
+}
+
+func TestWithCause(t *testing.T) {
+	orig := common.MyError{Msg: "Error from CallB"}
+	withCause := orig.WithCause(bare.CallC())
+	if orig.Cause != nil {
+		t.Errorf("WithCause() modified the receiver: Cause = %v", orig.Cause)
+	}
+	if withCause.Cause != bare.CallC() || withCause.Msg != orig.Msg {
+		t.Errorf("WithCause() = %#v, want Msg kept and Cause set to CallC", withCause)
+	}
+	if cleared := withCause.WithCause(nil); cleared.Unwrap() != nil {
+		t.Errorf("WithCause(nil).Unwrap() = %v, want nil", cleared.Unwrap())
+	}
+}
@@ -0,0 +1,19 @@
+package common
+
+import (
+	"context"
+	"errors"
+)
+
+func FromContext(ctx context.Context, msg string) error {
+	err := ctx.Err()
+	if err == nil {
+		return nil
+	}
+	return created(MyError{
+		Msg:      msg,
+		Cause:    err,
+		TimedOut: errors.Is(err, context.DeadlineExceeded),
+		Canceled: errors.Is(err, context.Canceled),
+	})
+}
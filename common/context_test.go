@@ -0,0 +1,49 @@
+package common_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mycodesmells/pkg-errors-example/common"
+)
+
+func TestFromContext(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		cause    error
+		timedOut bool
+		canceled bool
+	}{
+		{"canceled", canceled, context.Canceled, false, true},
+		{"deadline", expired, context.DeadlineExceeded, true, false},
+	}
+	for _, tt := range tests {
+		err := common.FromContext(tt.ctx, "Error from CallC")
+		me, ok := common.AsMyError(err)
+		if !ok {
+			t.Errorf("%s: FromContext() = %v, want a MyError", tt.name, err)
+			continue
+		}
+		if me.Msg != "Error from CallC" {
+			t.Errorf("%s: Msg = %q, want %q", tt.name, me.Msg, "Error from CallC")
+		}
+		if !errors.Is(err, tt.cause) {
+			t.Errorf("%s: errors.Is(err, %v) = false, want true", tt.name, tt.cause)
+		}
+		if me.TimedOut != tt.timedOut || me.Canceled != tt.canceled {
+			t.Errorf("%s: TimedOut, Canceled = %v, %v, want %v, %v", tt.name, me.TimedOut, me.Canceled, tt.timedOut, tt.canceled)
+		}
+	}
+
+	if err := common.FromContext(context.Background(), "Error from CallC"); err != nil {
+		t.Errorf("FromContext(live) = %v, want nil", err)
+	}
+}
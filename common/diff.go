@@ -0,0 +1,35 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Diff compares the canonical chains of want and got layer by layer,
+// innermost first, and describes every mismatch on its own line. It returns
+// an empty string when the chains match.
+func Diff(want, got error) string {
+	wl, gl := canonicalLayers(want), canonicalLayers(got)
+	var lines []string
+	for i := 0; i < len(wl) || i < len(gl); i++ {
+		switch {
+		case i >= len(gl):
+			lines = append(lines, fmt.Sprintf("layer %d: want %s, got nothing", i, wl[i]))
+		case i >= len(wl):
+			lines = append(lines, fmt.Sprintf("layer %d: want nothing, got %s", i, gl[i]))
+		case wl[i].mine != gl[i].mine:
+			lines = append(lines, fmt.Sprintf("layer %d: want %s, got %s", i, wl[i], gl[i]))
+		default:
+			if wl[i].code != gl[i].code {
+				lines = append(lines, fmt.Sprintf("layer %d: code want %d, got %d", i, wl[i].code, gl[i].code))
+			}
+			if wl[i].msg != gl[i].msg {
+				lines = append(lines, fmt.Sprintf("layer %d: message want %q, got %q", i, wl[i].msg, gl[i].msg))
+			}
+		}
+	}
+	if len(wl) != len(gl) {
+		lines = append(lines, fmt.Sprintf("depth: want %d layers, got %d", len(wl), len(gl)))
+	}
+	return strings.Join(lines, "\n")
+}
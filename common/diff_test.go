@@ -0,0 +1,32 @@
+package common_test
+
+import (
+	"testing"
+
+	"github.com/mycodesmells/pkg-errors-example/bare"
+	"github.com/mycodesmells/pkg-errors-example/common"
+	"github.com/mycodesmells/pkg-errors-example/wrap"
+	pkgerrors "github.com/pkg/errors"
+)
+
+func TestDiff(t *testing.T) {
+	renamed := pkgerrors.Wrap(pkgerrors.Wrap(common.NewError(404, "Error from CallC"), "Error from CallB"), "Error from CallX")
+	tests := []struct {
+		name      string
+		want, got error
+		diff      string
+	}{
+		{"equal", wrap.CallA(), wrap.CallA(), ""},
+		{"message", wrap.CallA(), renamed, `layer 2: message want "Error from CallA", got "Error from CallX"`},
+		{"depth", wrap.CallA(), bare.CallA(), "layer 0: code want 404, got 401\n" +
+			`layer 1: want "Error from CallB", got nothing` + "\n" +
+			`layer 2: want "Error from CallA", got nothing` + "\n" +
+			"depth: want 3 layers, got 1"},
+		{"nil", nil, nil, ""},
+	}
+	for _, tt := range tests {
+		if got := common.Diff(tt.want, tt.got); got != tt.diff {
+			t.Errorf("%s: Diff() = %q, want %q", tt.name, got, tt.diff)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+)
+
+func Errorf(format string, args ...interface{}) MyError {
+	return created(errorf(format, args...))
+}
+
+// Errorw is Errorf with an explicit cause. When the format also wraps errors
+// with %w, neither is lost: the cause becomes errors.Join(cause, wrapped).
+func Errorw(cause error, format string, args ...interface{}) MyError {
+	me := errorf(format, args...)
+	switch {
+	case me.Cause == nil:
+		me.Cause = cause
+	case cause != nil:
+		me.Cause = errors.Join(cause, me.Cause)
+	}
+	return created(me)
+}
+
+func errorf(format string, args ...interface{}) MyError {
+	err := fmt.Errorf(format, args...)
+	me := MyError{Msg: err.Error()}
+	switch w := err.(type) {
+	case interface{ Unwrap() error }:
+		me.Cause = w.Unwrap()
+	case interface{ Unwrap() []error }:
+		me.Cause = errors.Join(w.Unwrap()...)
+	}
+	return me
+}
@@ -0,0 +1,51 @@
+package common_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mycodesmells/pkg-errors-example/common"
+)
+
+func TestErrorf(t *testing.T) {
+	me := common.Errorf("call %s failed after %d tries", "CallC", 3)
+	if me.Msg != "call CallC failed after 3 tries" || me.Cause != nil {
+		t.Errorf("Errorf() = %#v, want the formatted message and no cause", me)
+	}
+
+	me = common.Errorf("lookup: %w", common.ErrNotFound)
+	if me.Msg != "lookup: not found" {
+		t.Errorf("Msg = %q, want %q", me.Msg, "lookup: not found")
+	}
+	if cause := errors.Unwrap(me); cause != common.ErrNotFound {
+		t.Errorf("errors.Unwrap() = %v, want ErrNotFound", cause)
+	}
+
+	me = common.Errorf("%w and %w", common.ErrInvalid, common.ErrTimeout)
+	if !errors.Is(me, common.ErrInvalid) || !errors.Is(me, common.ErrTimeout) {
+		t.Error("Errorf with two %w verbs lost one of the causes")
+	}
+}
+
+func TestErrorw(t *testing.T) {
+	me := common.Errorw(common.ErrTimeout, "CallC took %dms", 1500)
+	if me.Msg != "CallC took 1500ms" {
+		t.Errorf("Msg = %q, want %q", me.Msg, "CallC took 1500ms")
+	}
+	if cause := errors.Unwrap(me); cause != common.ErrTimeout {
+		t.Errorf("errors.Unwrap() = %v, want ErrTimeout", cause)
+	}
+
+	me = common.Errorw(common.ErrTimeout, "lookup: %w", common.ErrNotFound)
+	if me.Msg != "lookup: not found" {
+		t.Errorf("Msg = %q, want %q", me.Msg, "lookup: not found")
+	}
+	if !errors.Is(me, common.ErrTimeout) || !errors.Is(me, common.ErrNotFound) {
+		t.Error("Errorw with %w lost either the explicit or the wrapped cause")
+	}
+
+	me = common.Errorw(nil, "lookup: %w", common.ErrNotFound)
+	if cause := errors.Unwrap(me); cause != common.ErrNotFound {
+		t.Errorf("Errorw(nil) errors.Unwrap() = %v, want ErrNotFound", cause)
+	}
+}
@@ -0,0 +1,67 @@
+package common
+
+import "sort"
+
+// fieldSet is never modified once built, and is held by pointer so MyError
+// stays comparable. Two errors are only equal if they share the same set.
+type fieldSet struct {
+	m map[string]interface{}
+}
+
+func (me MyError) fieldMap() map[string]interface{} {
+	if me.fields == nil {
+		return nil
+	}
+	return me.fields.m
+}
+
+// Fields returns a copy of the fields attached with WithField.
+func (me MyError) Fields() map[string]interface{} {
+	src := me.fieldMap()
+	if src == nil {
+		return nil
+	}
+	fields := make(map[string]interface{}, len(src))
+	for k, v := range src {
+		fields[k] = v
+	}
+	return fields
+}
+
+func (me MyError) WithField(key string, value interface{}) MyError {
+	src := me.fieldMap()
+	fields := make(map[string]interface{}, len(src)+1)
+	for k, v := range src {
+		fields[k] = v
+	}
+	fields[key] = value
+	me.fields = &fieldSet{m: fields}
+	return me
+}
+
+// FieldsOf merges the fields of every MyError in the chain. When the same key
+// is set on several layers, the outermost value wins.
+func FieldsOf(err error) map[string]interface{} {
+	fields := map[string]interface{}{}
+	for _, e := range Chain(err) {
+		me, ok := layer(e)
+		if !ok {
+			continue
+		}
+		for k, v := range me.fieldMap() {
+			if _, ok := fields[k]; !ok {
+				fields[k] = v
+			}
+		}
+	}
+	return fields
+}
+
+func sortedKeys(fields map[string]interface{}) []string {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,48 @@
+package common_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mycodesmells/pkg-errors-example/common"
+	pkgerrors "github.com/pkg/errors"
+)
+
+func TestFieldsOf(t *testing.T) {
+	callC := common.MyError{Msg: "Error from CallC"}.WithField("c", 3).WithField("request", "c")
+	callB := common.MyError{Msg: "Error from CallB", Cause: callC}.WithField("b", 2).WithField("request", "b")
+	callA := pkgerrors.Wrap(common.MyError{Msg: "Error from CallA", Cause: callB}.WithField("a", 1), "outer")
+
+	got := common.FieldsOf(callA)
+	want := map[string]interface{}{"a": 1, "b": 2, "c": 3, "request": "b"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("FieldsOf() = %v, want %v", got, want)
+	}
+	if n := len(callC.Fields()); n != 2 {
+		t.Errorf("WithField modified the original: %d fields, want 2", n)
+	}
+}
+
+func TestFieldsCopy(t *testing.T) {
+	me := common.MyError{Msg: "x"}.WithField("k", "v")
+	me.Fields()["k"] = "changed"
+	if got := me.Fields()["k"]; got != "v" {
+		t.Errorf("Fields()[k] = %v after editing the returned map, want v", got)
+	}
+}
+
+func TestMyErrorComparable(t *testing.T) {
+	var a, b error = common.MyError{Msg: "x"}, common.MyError{Msg: "x"}
+	if a != b {
+		t.Error("equal MyError values compare unequal")
+	}
+	withFields := common.MyError{Msg: "x"}.WithField("k", "v")
+	var c, d error = withFields, withFields
+	if c != d || c == a {
+		t.Error("MyError values with fields do not compare by identity of their fields")
+	}
+	seen := map[error]bool{a: true}
+	if !seen[b] {
+		t.Error("MyError does not work as a map key")
+	}
+}
@@ -0,0 +1,18 @@
+package common
+
+import "strings"
+
+func Flatten(err error, sep string) string {
+	chain := Chain(err)
+	msgs := make([]string, 0, len(chain))
+	for i, e := range chain {
+		var cause error
+		if i+1 < len(chain) {
+			cause = chain[i+1]
+		}
+		if msg := local(e, cause); msg != "" {
+			msgs = append(msgs, msg)
+		}
+	}
+	return strings.Join(msgs, sep)
+}
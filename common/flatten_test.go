@@ -0,0 +1,30 @@
+package common_test
+
+import (
+	"testing"
+
+	"github.com/mycodesmells/pkg-errors-example/bare"
+	"github.com/mycodesmells/pkg-errors-example/common"
+	"github.com/mycodesmells/pkg-errors-example/concat"
+	"github.com/mycodesmells/pkg-errors-example/wrap"
+)
+
+func TestFlatten(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		sep  string
+		want string
+	}{
+		{"wrap", wrap.CallA(), ": ", "Error from CallA: Error from CallB: Error from CallC"},
+		{"concat", concat.CallA(), ": ", "Error from CallA: Error from CallB: Error from CallC"},
+		{"bare", bare.CallA(), ": ", "Error from CallC"},
+		{"separator", wrap.CallA(), " | ", "Error from CallA | Error from CallB | Error from CallC"},
+		{"nil", nil, ": ", ""},
+	}
+	for _, tt := range tests {
+		if got := common.Flatten(tt.err, tt.sep); got != tt.want {
+			t.Errorf("%s: Flatten() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package common
+
+import (
+	"fmt"
+	"io"
+)
+
+// verboseWriter prints a chain one layer per line for %+v. MyError layers and
+// joined errors are expanded here, sharing a budget of maxDepth layers so a
+// cyclic chain terminates; any other error is handed its own %+v and ends
+// its branch.
+type verboseWriter struct {
+	s       fmt.State
+	budget  int
+	written bool
+}
+
+func writeVerbose(s fmt.State, err error) {
+	w := &verboseWriter{s: s, budget: maxDepth}
+	w.write(err)
+}
+
+func (w *verboseWriter) write(err error) {
+	for ; err != nil && w.budget > 0; err = next(err) {
+		w.budget--
+		if j, ok := err.(*joinError); ok {
+			for _, child := range j.errs {
+				w.write(child)
+			}
+			return
+		}
+		if w.written {
+			io.WriteString(w.s, "\n")
+		}
+		w.written = true
+		if me, ok := layer(err); ok {
+			io.WriteString(w.s, me.Error())
+			continue
+		}
+		fmt.Fprintf(w.s, "%+v", err)
+		return
+	}
+}
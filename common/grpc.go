@@ -0,0 +1,45 @@
+package common
+
+import (
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var grpcCodes = map[int]codes.Code{
+	http.StatusBadRequest:         codes.InvalidArgument,
+	http.StatusUnauthorized:       codes.Unauthenticated,
+	http.StatusForbidden:          codes.PermissionDenied,
+	http.StatusNotFound:           codes.NotFound,
+	http.StatusConflict:           codes.AlreadyExists,
+	http.StatusTooManyRequests:    codes.ResourceExhausted,
+	499:                           codes.Canceled,
+	http.StatusNotImplemented:     codes.Unimplemented,
+	http.StatusServiceUnavailable: codes.Unavailable,
+	http.StatusGatewayTimeout:     codes.DeadlineExceeded,
+}
+
+func (me MyError) GRPCStatus() *status.Status {
+	return status.New(grpcCode(HTTPStatus(me)), me.Error())
+}
+
+// GRPCStatus converts err to a gRPC status, going through the same code to
+// HTTP status mapping as HTTPStatus. Errors without a MyError in the chain
+// become codes.Internal.
+func GRPCStatus(err error) *status.Status {
+	if err == nil {
+		return nil
+	}
+	if _, ok := AsMyError(err); !ok {
+		return status.New(codes.Internal, err.Error())
+	}
+	return status.New(grpcCode(HTTPStatus(err)), err.Error())
+}
+
+func grpcCode(httpStatus int) codes.Code {
+	if code, ok := grpcCodes[httpStatus]; ok {
+		return code
+	}
+	return codes.Internal
+}
@@ -0,0 +1,50 @@
+package common_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mycodesmells/pkg-errors-example/bare"
+	"github.com/mycodesmells/pkg-errors-example/common"
+	"github.com/mycodesmells/pkg-errors-example/wrap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGRPCStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{"mapped", wrap.CallA(), codes.NotFound},
+		{"unmapped code", common.NewError(9003, "odd"), codes.Internal},
+		{"no MyError", errors.New("plain"), codes.Internal},
+		{"code-0 wrapper", common.Errorw(wrap.CallA(), "outer"), codes.NotFound},
+	}
+	for _, tt := range tests {
+		st := common.GRPCStatus(tt.err)
+		if st.Code() != tt.want {
+			t.Errorf("%s: GRPCStatus().Code() = %v, want %v", tt.name, st.Code(), tt.want)
+		}
+		if st.Message() != tt.err.Error() {
+			t.Errorf("%s: GRPCStatus().Message() = %q, want %q", tt.name, st.Message(), tt.err.Error())
+		}
+	}
+	if st := common.GRPCStatus(nil); st != nil {
+		t.Errorf("GRPCStatus(nil) = %v, want nil", st)
+	}
+}
+
+func TestGRPCStatusMethodCodeZero(t *testing.T) {
+	if got := common.Wrap(bare.CallA(), "outer").GRPCStatus().Code(); got != codes.Unauthenticated {
+		t.Errorf("GRPCStatus().Code() of a code-0 wrapper = %v, want %v", got, codes.Unauthenticated)
+	}
+}
+
+func TestGRPCStatusInterface(t *testing.T) {
+	st, ok := status.FromError(bare.CallA())
+	if !ok || st.Code() != codes.Unauthenticated || st.Message() != "Errrrr: Error from CallC" {
+		t.Errorf("status.FromError() = %v, %v, want Unauthenticated with the MyError message", st, ok)
+	}
+}
@@ -0,0 +1,22 @@
+package common
+
+import "sync/atomic"
+
+type hook func(MyError)
+
+var onError atomic.Value
+
+// OnError registers fn to be called synchronously each time NewError,
+// Errorf, Errorw, Wrap, Here, NewInterned, FromContext or Recover builds a
+// MyError. Struct literals such as MyError{Msg: "..."} and copies made by the
+// With* methods never reach the hook. Passing nil removes it.
+func OnError(fn func(MyError)) {
+	onError.Store(hook(fn))
+}
+
+func created(me MyError) MyError {
+	if fn, _ := onError.Load().(hook); fn != nil {
+		fn(me)
+	}
+	return me
+}
@@ -0,0 +1,56 @@
+package common_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mycodesmells/pkg-errors-example/common"
+)
+
+func TestOnError(t *testing.T) {
+	defer common.OnError(nil)
+	var got []string
+	common.OnError(func(me common.MyError) {
+		got = append(got, me.Msg)
+	})
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	tests := []struct {
+		name string
+		make func()
+		want int
+	}{
+		{"NewError", func() { common.NewError(404, "m") }, 1},
+		{"Errorf", func() { common.Errorf("%s", "m") }, 1},
+		{"Errorw", func() { common.Errorw(common.ErrTimeout, "m") }, 1},
+		{"Wrap", func() { common.Wrap(common.ErrNotFound, "m") }, 1},
+		{"Here", func() { common.Here("m") }, 1},
+		{"NewInterned", func() { common.NewInterned("m") }, 1},
+		{"FromContext", func() { common.FromContext(canceled, "m") }, 1},
+		{"FromContext live", func() { common.FromContext(context.Background(), "m") }, 0},
+		{"Recover", func() {
+			var err error
+			func() {
+				defer common.Recover("m", &err)
+				panic("boom")
+			}()
+		}, 1},
+		{"literal", func() { _ = common.MyError{Msg: "m"} }, 0},
+		{"WithField", func() { common.MyError{Msg: "m"}.WithField("k", 1) }, 0},
+	}
+	for _, tt := range tests {
+		got = nil
+		tt.make()
+		if len(got) != tt.want {
+			t.Errorf("%s: hook fired %d times, want %d", tt.name, len(got), tt.want)
+		}
+	}
+}
+
+func TestOnErrorUnset(t *testing.T) {
+	common.OnError(nil)
+	if me := common.NewError(404, "Error from CallC"); me.Msg != "Error from CallC" {
+		t.Errorf("NewError() without a hook = %v", me)
+	}
+}
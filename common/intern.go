@@ -0,0 +1,21 @@
+package common
+
+import "sync"
+
+var interned sync.Map
+
+// Intern returns a canonical copy of msg so that errors built from the same
+// message share one backing string. Interned strings are never released, so
+// only intern messages drawn from a fixed set; a map lookup is traded for the
+// allocation on every call.
+func Intern(msg string) string {
+	if v, ok := interned.Load(msg); ok {
+		return v.(string)
+	}
+	v, _ := interned.LoadOrStore(msg, msg)
+	return v.(string)
+}
+
+func NewInterned(msg string) MyError {
+	return created(MyError{Msg: Intern(msg)})
+}
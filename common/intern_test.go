@@ -0,0 +1,61 @@
+package common
+
+import (
+	"fmt"
+	"testing"
+	"unsafe"
+)
+
+func internedLen() int {
+	n := 0
+	interned.Range(func(_, _ interface{}) bool {
+		n++
+		return true
+	})
+	return n
+}
+
+func TestIntern(t *testing.T) {
+	a := Intern(fmt.Sprintf("Error from %s", "CallC"))
+	b := Intern(fmt.Sprintf("Error from %s", "CallC"))
+	if a != b || unsafe.StringData(a) != unsafe.StringData(b) {
+		t.Error("Intern() returned different backing strings for the same message")
+	}
+	if me := NewInterned("Error from CallC"); me.Msg != a {
+		t.Errorf("NewInterned().Msg = %q, want %q", me.Msg, a)
+	}
+}
+
+func TestInternBounded(t *testing.T) {
+	msgs := []string{"Error from CallA", "Error from CallB", "Error from CallC"}
+	for _, msg := range msgs {
+		Intern(msg)
+	}
+	before := internedLen()
+	for i := 0; i < 1000; i++ {
+		NewInterned(msgs[i%len(msgs)])
+	}
+	if after := internedLen(); after != before {
+		t.Errorf("interned grew from %d to %d entries for a fixed message set", before, after)
+	}
+}
+
+func BenchmarkNewError(b *testing.B) {
+	msg := "Error from CallC"
+	b.Run("plain", func(b *testing.B) {
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				_ = NewError(0, fmt.Sprint(msg))
+			}
+		})
+	})
+	b.Run("interned", func(b *testing.B) {
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				_ = NewInterned(fmt.Sprint(msg))
+			}
+		})
+	})
+}
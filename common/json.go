@@ -0,0 +1,61 @@
+package common
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+type jsonError struct {
+	Message string          `json:"message"`
+	Code    int             `json:"code,omitempty"`
+	Cause   json.RawMessage `json:"cause,omitempty"`
+}
+
+func (me MyError) MarshalJSON() ([]byte, error) {
+	je := jsonError{Message: me.Msg, Code: me.Code}
+	var cause interface{}
+	switch c := me.Cause.(type) {
+	case nil:
+	case MyError:
+		cause = c
+	case *MyError:
+		if c != nil {
+			cause = c
+		}
+	default:
+		cause = c.Error()
+	}
+	if cause != nil {
+		raw, err := json.Marshal(cause)
+		if err != nil {
+			return nil, err
+		}
+		je.Cause = raw
+	}
+	return json.Marshal(je)
+}
+
+func (me *MyError) UnmarshalJSON(data []byte) error {
+	var je jsonError
+	if err := json.Unmarshal(data, &je); err != nil {
+		return err
+	}
+	*me = MyError{Code: je.Code, Msg: je.Message}
+	if len(je.Cause) == 0 || string(je.Cause) == "null" {
+		return nil
+	}
+	if je.Cause[0] == '"' {
+		var msg string
+		if err := json.Unmarshal(je.Cause, &msg); err != nil {
+			return err
+		}
+		me.Cause = errors.New(msg)
+		return nil
+	}
+	var cause MyError
+	if err := json.Unmarshal(je.Cause, &cause); err != nil {
+		return err
+	}
+	me.Cause = cause
+	return nil
+}
@@ -0,0 +1,63 @@
+package common_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/mycodesmells/pkg-errors-example/common"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		err  common.MyError
+		want string
+	}{
+		{"no code", common.MyError{Msg: "a"}, `{"message":"a"}`},
+		{"code", common.NewError(404, "a"), `{"message":"a","code":404}`},
+		{"MyError cause", common.MyError{Msg: "a", Cause: common.NewError(1, "b")}, `{"message":"a","cause":{"message":"b","code":1}}`},
+		{"plain cause", common.MyError{Msg: "a", Cause: errors.New("b")}, `{"message":"a","cause":"b"}`},
+		{"nil pointer cause", common.MyError{Msg: "a", Cause: (*common.MyError)(nil)}, `{"message":"a"}`},
+	}
+	for _, tt := range tests {
+		got, err := json.Marshal(tt.err)
+		if err != nil || string(got) != tt.want {
+			t.Errorf("%s: json.Marshal() = %s, %v, want %s", tt.name, got, err, tt.want)
+		}
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		err  common.MyError
+	}{
+		{"without cause", common.NewError(404, "Error from CallC")},
+		{"nested MyError cause", common.MyError{Code: 500, Msg: "outer", Cause: common.MyError{Code: 404, Msg: "inner", Cause: errors.New("root")}}},
+	}
+	for _, tt := range tests {
+		data, err := json.Marshal(tt.err)
+		if err != nil {
+			t.Fatalf("%s: json.Marshal() error = %v", tt.name, err)
+		}
+		var got common.MyError
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatalf("%s: json.Unmarshal() error = %v", tt.name, err)
+		}
+		if got.Code != tt.err.Code || got.Msg != tt.err.Msg {
+			t.Errorf("%s: round trip = %+v, want %+v", tt.name, got, tt.err)
+		}
+		again, _ := json.Marshal(got)
+		if string(again) != string(data) {
+			t.Errorf("%s: re-marshaled = %s, want %s", tt.name, again, data)
+		}
+	}
+
+	var got common.MyError
+	json.Unmarshal([]byte(`{"message":"a","cause":{"message":"b","code":2}}`), &got)
+	cause, ok := got.Cause.(common.MyError)
+	if !ok || cause.Code != 2 || cause.Msg != "b" {
+		t.Errorf("nested cause = %#v, want MyError{Code: 2, Msg: b}", got.Cause)
+	}
+}
@@ -0,0 +1,147 @@
+package common
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// MultiError collects errors from independent operations. Every method is safe
+// on a nil *MultiError, which behaves as an empty one; Add on it discards err.
+type MultiError struct {
+	mu   sync.Mutex
+	errs []error
+}
+
+func (m *MultiError) Add(err error) {
+	if m == nil || err == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.errs = append(m.errs, err)
+}
+
+func (m *MultiError) ErrorOrNil() error {
+	if m == nil {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.errs) == 0 {
+		return nil
+	}
+	return m
+}
+
+// Error lists the child messages one per line, sorted so the output does not
+// depend on the order errors were added in.
+func (m *MultiError) Error() string {
+	if m == nil {
+		return ""
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	msgs := make([]string, len(m.errs))
+	for i, err := range m.errs {
+		msgs[i] = err.Error()
+	}
+	sort.Strings(msgs)
+	return strings.Join(msgs, "\n")
+}
+
+func (m *MultiError) Unwrap() []error {
+	if m == nil {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]error(nil), m.errs...)
+}
+
+// Dedup drops errors whose innermost MyError has the same message and code as
+// an earlier one, keeping the first seen. Errors without a MyError are kept.
+func (m *MultiError) Dedup() {
+	type key struct {
+		code int
+		msg  string
+	}
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	seen := map[key]bool{}
+	errs := m.errs[:0]
+	for _, err := range m.errs {
+		if me, ok := innermost(err); ok {
+			k := key{code: me.Code, msg: me.Msg}
+			if seen[k] {
+				continue
+			}
+			seen[k] = true
+		}
+		errs = append(errs, err)
+	}
+	m.errs = errs
+}
+
+func GroupByCode(errs []error) map[int][]error {
+	groups := map[int][]error{}
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		me, _ := innermost(err)
+		groups[me.Code] = append(groups[me.Code], err)
+	}
+	return groups
+}
+
+type joinError struct {
+	errs []error
+}
+
+// Join behaves like errors.Join, and additionally prints every joined error
+// with its own verbose formatting under %+v.
+func Join(errs ...error) error {
+	var joined []error
+	for _, err := range errs {
+		if err != nil {
+			joined = append(joined, err)
+		}
+	}
+	if len(joined) == 0 {
+		return nil
+	}
+	return &joinError{errs: joined}
+}
+
+func (j *joinError) Error() string {
+	msgs := make([]string, len(j.errs))
+	for i, err := range j.errs {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+func (j *joinError) Unwrap() []error {
+	return j.errs
+}
+
+func (j *joinError) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			writeVerbose(s, j)
+			return
+		}
+		fallthrough
+	case 's':
+		io.WriteString(s, j.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", j.Error())
+	}
+}
@@ -0,0 +1,156 @@
+package common_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/mycodesmells/pkg-errors-example/bare"
+	"github.com/mycodesmells/pkg-errors-example/common"
+	"github.com/mycodesmells/pkg-errors-example/concat"
+	"github.com/mycodesmells/pkg-errors-example/wrap"
+)
+
+func ExampleMultiError() {
+	var errs common.MultiError
+	errs.Add(wrap.CallC())
+	errs.Add(concat.CallC())
+	errs.Add(bare.CallC())
+
+	err := errs.ErrorOrNil()
+	fmt.Println(err)
+	fmt.Println(errors.Is(err, common.MyError{Msg: "Error from CallC"}))
+	// Output:
+	// Errrrr: Error from CallC
+	// Errrrr: Error from CallC
+	// Errrrr: Error from CallC
+	// true
+}
+
+func TestMultiError(t *testing.T) {
+	var errs common.MultiError
+	if err := errs.ErrorOrNil(); err != nil {
+		t.Fatalf("empty ErrorOrNil() = %v, want nil", err)
+	}
+	errs.Add(nil)
+	if err := errs.ErrorOrNil(); err != nil {
+		t.Fatalf("ErrorOrNil() after Add(nil) = %v, want nil", err)
+	}
+
+	errs.Add(wrap.CallA())
+	errs.Add(errors.New("plain"))
+	err := errs.ErrorOrNil()
+	if got, want := err.Error(), "Error from CallA: Error from CallB: Errrrr: Error from CallC\nplain"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if !errors.Is(err, common.WithCode(404)) {
+		t.Error("errors.Is did not find the wrapped MyError")
+	}
+	var me common.MyError
+	if !errors.As(err, &me) || me.Code != 404 {
+		t.Errorf("errors.As() = %v, want the 404 MyError", me)
+	}
+}
+
+func TestMultiErrorNil(t *testing.T) {
+	var errs *common.MultiError
+	errs.Add(errors.New("dropped"))
+	errs.Dedup()
+	if errs.ErrorOrNil() != nil || errs.Error() != "" || errs.Unwrap() != nil {
+		t.Error("nil *MultiError does not behave as an empty one")
+	}
+}
+
+func TestGroupByCode(t *testing.T) {
+	plain := errors.New("plain")
+	wrapB, wrapA := wrap.CallB(), wrap.CallA()
+	errs := []error{wrapA, concat.CallA(), nil, plain, wrapB, bare.CallA()}
+
+	groups := common.GroupByCode(errs)
+	if len(groups) != 4 {
+		t.Fatalf("GroupByCode() has %d groups, want 4: %v", len(groups), groups)
+	}
+	tests := []struct {
+		code int
+		want []error
+	}{
+		{404, []error{wrapA, wrapB}},
+		{0, []error{plain}},
+	}
+	for _, tt := range tests {
+		got := groups[tt.code]
+		if len(got) != len(tt.want) {
+			t.Errorf("code %d: got %d errors, want %d", tt.code, len(got), len(tt.want))
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("code %d: error %d = %v, want %v", tt.code, i, got[i], tt.want[i])
+			}
+		}
+	}
+	if len(groups[403]) != 1 || len(groups[401]) != 1 {
+		t.Errorf("GroupByCode() = %v, want one 403 and one 401 error", groups)
+	}
+	if groups := common.GroupByCode(nil); len(groups) != 0 {
+		t.Errorf("GroupByCode(nil) = %v, want empty", groups)
+	}
+}
+
+func TestDedup(t *testing.T) {
+	var errs common.MultiError
+	first := wrap.CallA()
+	plain := errors.New("plain")
+	errs.Add(first)
+	errs.Add(wrap.CallB())
+	errs.Add(concat.CallA())
+	errs.Add(plain)
+	errs.Add(errors.New("plain"))
+	errs.Add(common.NewError(500, "Error from CallC"))
+	errs.Dedup()
+
+	got := errs.Unwrap()
+	if len(got) != 5 {
+		t.Fatalf("Dedup() kept %d errors, want 5: %v", len(got), got)
+	}
+	if got[0] != first {
+		t.Errorf("Dedup() kept %v first, want the first error added", got[0])
+	}
+	if got[1].Error() != concat.CallA().Error() || got[2] != plain {
+		t.Errorf("Dedup() = %v, want the remaining errors in insertion order", got)
+	}
+}
+
+func TestJoin(t *testing.T) {
+	plain := errors.New("plain")
+	joined := common.Join(plain, nil, wrap.CallA(), bare.CallA())
+
+	if got, want := joined.Error(), "plain\n"+wrap.CallA().Error()+"\n"+bare.CallA().Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if common.Join(nil, nil) != nil {
+		t.Error("Join(nil, nil) != nil")
+	}
+	if !common.SameError(common.Join(wrap.CallA()), common.MyError{Msg: "Error from CallC"}) {
+		t.Error("SameError(Join(wrap.CallA()), CallC) = false, want true")
+	}
+	if code, ok := common.CodeOf(joined); !ok || code != 404 {
+		t.Errorf("CodeOf() = %d, %v, want the first branch's 404", code, ok)
+	}
+	if code, ok := common.CodeOf(fmt.Errorf("%w; %w", plain, concat.CallA())); !ok || code != 403 {
+		t.Errorf("CodeOf(fmt.Errorf with two %%w) = %d, %v, want 403", code, ok)
+	}
+	if _, ok := common.CodeOf(common.Join(plain)); ok {
+		t.Error("CodeOf() found a code in a join without MyErrors")
+	}
+	if !errors.Is(joined, plain) || !errors.Is(joined, common.WithCode(401)) {
+		t.Error("errors.Is does not reach every branch of the join")
+	}
+	var me common.MyError
+	if !errors.As(joined, &me) || me.Code != 404 {
+		t.Errorf("errors.As() = %v, want the 404 MyError from the first branch", me)
+	}
+	if n := len(common.Chain(joined)); n != 1 {
+		t.Errorf("len(Chain(join)) = %d, want 1: Chain does not descend into branches", n)
+	}
+}
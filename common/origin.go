@@ -0,0 +1,24 @@
+package common
+
+import (
+	"fmt"
+	"runtime"
+)
+
+func Here(msg string) MyError {
+	me := MyError{Msg: msg}
+	if pc, _, line, ok := runtime.Caller(1); ok {
+		if fn := runtime.FuncForPC(pc); fn != nil {
+			me.OriginFunc = fn.Name()
+		}
+		me.OriginLine = line
+	}
+	return created(me)
+}
+
+func (me MyError) Origin() string {
+	if me.OriginFunc == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", me.OriginFunc, me.OriginLine)
+}
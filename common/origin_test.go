@@ -0,0 +1,28 @@
+package common_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mycodesmells/pkg-errors-example/common"
+)
+
+func failingHelper() common.MyError {
+	return common.Here("boom")
+}
+
+func TestHere(t *testing.T) {
+	me := failingHelper()
+	if me.Msg != "boom" {
+		t.Errorf("Msg = %q, want boom", me.Msg)
+	}
+	if origin := me.Origin(); !strings.Contains(origin, "failingHelper") {
+		t.Errorf("Origin() = %q, want it to name failingHelper", origin)
+	}
+	if me.OriginLine == 0 {
+		t.Error("OriginLine = 0, want the caller's line")
+	}
+	if origin := (common.MyError{Msg: "boom"}).Origin(); origin != "" {
+		t.Errorf("literal Origin() = %q, want empty", origin)
+	}
+}
@@ -0,0 +1,29 @@
+package common
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// Recover turns a panic into a MyError with a stack trace and stores it in
+// *err. It must be deferred directly, as recover only stops a panic when
+// called by the deferred function itself:
+//
+//	func run() (err error) {
+//		defer common.Recover("run panicked", &err)
+//		...
+//	}
+//
+// When there is no panic *err is left as it is.
+func Recover(msgPrefix string, err *error) {
+	v := recover()
+	if v == nil {
+		return
+	}
+	me := MyError{Msg: fmt.Sprintf("%s: %v", msgPrefix, v)}
+	if cause, ok := v.(error); ok {
+		me.Cause = cause
+	}
+	*err = errors.WithStack(created(me))
+}
@@ -0,0 +1,52 @@
+package common_test
+
+import (
+	"errors"
+	"runtime"
+	"testing"
+
+	"github.com/mycodesmells/pkg-errors-example/common"
+	pkgerrors "github.com/pkg/errors"
+)
+
+func recovered(v interface{}) (err error) {
+	defer common.Recover("run panicked", &err)
+	panic(v)
+}
+
+func TestRecover(t *testing.T) {
+	err := recovered("boom")
+	me, ok := common.AsMyError(err)
+	if !ok || me.Msg != "run panicked: boom" || me.Cause != nil {
+		t.Errorf("Recover(string) = %#v, want message %q and no cause", me, "run panicked: boom")
+	}
+	var st interface{ StackTrace() pkgerrors.StackTrace }
+	if !errors.As(err, &st) {
+		t.Error("Recover() result has no stack trace")
+	}
+
+	err = recovered(common.ErrInvalid)
+	if me, _ := common.AsMyError(err); me.Msg != "run panicked: invalid" {
+		t.Errorf("Msg = %q, want %q", me.Msg, "run panicked: invalid")
+	}
+	if !errors.Is(err, common.ErrInvalid) {
+		t.Error("Recover(error) does not keep the panic value as the cause")
+	}
+
+	err = recovered(nil)
+	var pe *runtime.PanicNilError
+	if !errors.As(err, &pe) {
+		t.Errorf("Recover(nil panic) = %v, want a *runtime.PanicNilError cause", err)
+	}
+}
+
+func TestRecoverNoPanic(t *testing.T) {
+	keep := errors.New("keep")
+	run := func() (err error) {
+		defer common.Recover("run panicked", &err)
+		return keep
+	}
+	if err := run(); err != keep {
+		t.Errorf("Recover() without a panic = %v, want the returned error", err)
+	}
+}
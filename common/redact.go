@@ -0,0 +1,282 @@
+package common
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const redactedMsg = "[REDACTED]"
+
+type stackTracer interface {
+	StackTrace() errors.StackTrace
+}
+
+type coder interface {
+	Code() int
+}
+
+type redacted struct {
+	prefix string
+	suffix string
+	nested bool
+	cause  error
+	orig   error
+}
+
+// maxRedacted bounds the number of errors Redact visits across all joined
+// branches. Anything past it is replaced by MyError{Msg: "[REDACTED]"}, so an
+// oversized or cyclic tree is cut short rather than leaked.
+const maxRedacted = 100 * maxDepth
+
+// Redact returns a copy of the chain with the messages of sensitive MyError
+// layers replaced by [REDACTED], descending into joined errors as well. Other
+// layers are rebuilt around the redacted cause so their own text, codes and
+// stack traces are kept, and a MyError whose Msg embeds its cause's text, as
+// Errorf with %w produces, gets the redacted text instead. *MyError layers
+// stay pointers, parts without sensitive layers are reused as they are, and
+// err itself is left untouched.
+func Redact(err error) error {
+	budget := maxRedacted
+	out, _ := redactChain(err, &budget)
+	return out
+}
+
+func redactChain(err error, budget *int) (error, bool) {
+	if err == nil {
+		return nil, false
+	}
+	chain := Chain(err)
+	if *budget < len(chain) {
+		*budget = 0
+		return MyError{Msg: redactedMsg}, true
+	}
+	*budget -= len(chain)
+
+	// A chain cut short by maxDepth is cyclic, so its innermost layer must
+	// not keep the cause that leads back into the cycle.
+	truncated := len(chain) == maxDepth && next(chain[len(chain)-1]) != nil
+	var out error
+	changed := false
+	for i := len(chain) - 1; i >= 0; i-- {
+		e := chain[i]
+		innermost := i == len(chain)-1
+		if me, ok := layer(e); ok {
+			if !changed && !me.Sensitive && !(innermost && truncated) {
+				out = e
+				continue
+			}
+			if me.Sensitive {
+				me.Msg = redactedMsg
+				me.MessageKey, me.args = "", nil
+			} else if !innermost {
+				me.Msg = replaceText(me.Msg, chain[i+1], out)
+			}
+			me.Cause = out
+			if _, ok := e.(*MyError); ok {
+				out = &me
+			} else {
+				out = me
+			}
+			changed = true
+			continue
+		}
+		switch {
+		case innermost && truncated:
+			out, changed = MyError{Msg: redactedMsg}, true
+		case innermost:
+			out, changed = redactMulti(e, budget)
+		case !changed:
+			out = e
+		default:
+			prefix, suffix, nested := split(e, chain[i+1])
+			out = rebuild(&redacted{prefix: prefix, suffix: suffix, nested: nested, cause: out, orig: e})
+		}
+	}
+	return out, changed
+}
+
+// redactMulti redacts every child of an Unwrap() []error error. Join and
+// MultiError are rebuilt as such; any other type becomes a redactedMulti
+// whose text has each child's original message swapped for the redacted one.
+func redactMulti(err error, budget *int) (error, bool) {
+	multi, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return err, false
+	}
+	children := multi.Unwrap()
+	errs := make([]error, len(children))
+	changed := false
+	for i, child := range children {
+		var c bool
+		errs[i], c = redactChain(child, budget)
+		changed = changed || c
+	}
+	if !changed {
+		return err, false
+	}
+	switch err.(type) {
+	case *joinError:
+		return &joinError{errs: errs}, true
+	case *MultiError:
+		return &MultiError{errs: errs}, true
+	}
+	msg := err.Error()
+	for i, child := range children {
+		if child != nil {
+			msg = replaceText(msg, child, errs[i])
+		}
+	}
+	return &redactedMulti{msg: msg, errs: errs}, true
+}
+
+// replaceText swaps the text of orig in msg for that of its redacted copy.
+// When orig's text does not appear as a whole, as with the errors.Join cause
+// Errorf builds for several %w verbs, each joined child is swapped instead.
+func replaceText(msg string, orig, redacted error) string {
+	if text := orig.Error(); text != "" && strings.Contains(msg, text) {
+		return strings.ReplaceAll(msg, text, redacted.Error())
+	}
+	om, ok := orig.(interface{ Unwrap() []error })
+	if !ok {
+		return msg
+	}
+	rm, ok := redacted.(interface{ Unwrap() []error })
+	if !ok {
+		return msg
+	}
+	oc, rc := om.Unwrap(), rm.Unwrap()
+	if len(oc) != len(rc) {
+		return msg
+	}
+	for i := range oc {
+		if oc[i] != nil && rc[i] != nil {
+			msg = replaceText(msg, oc[i], rc[i])
+		}
+	}
+	return msg
+}
+
+type redactedMulti struct {
+	msg  string
+	errs []error
+}
+
+func (r *redactedMulti) Error() string {
+	return r.msg
+}
+
+func (r *redactedMulti) Unwrap() []error {
+	return r.errs
+}
+
+// rebuild gives r the StackTrace and Code methods of the layer it replaces,
+// and only those, so errors.As and wrap.CodesInChain see the same shape.
+func rebuild(r *redacted) error {
+	st, hasStack := r.orig.(stackTracer)
+	c, hasCode := r.orig.(coder)
+	switch {
+	case hasStack && hasCode:
+		return &redactedStackCode{redacted: r, stack: st.StackTrace(), code: c.Code()}
+	case hasStack:
+		return &redactedStack{redacted: r, stack: st.StackTrace()}
+	case hasCode:
+		return &redactedCode{redacted: r, code: c.Code()}
+	}
+	return r
+}
+
+type redactedStack struct {
+	*redacted
+	stack errors.StackTrace
+}
+
+func (r *redactedStack) StackTrace() errors.StackTrace {
+	return r.stack
+}
+
+type redactedCode struct {
+	*redacted
+	code int
+}
+
+func (r *redactedCode) Code() int {
+	return r.code
+}
+
+type redactedStackCode struct {
+	*redacted
+	stack errors.StackTrace
+	code  int
+}
+
+func (r *redactedStackCode) StackTrace() errors.StackTrace {
+	return r.stack
+}
+
+func (r *redactedStackCode) Code() int {
+	return r.code
+}
+
+func (r *redacted) Error() string {
+	if !r.nested {
+		return r.prefix
+	}
+	return r.prefix + r.cause.Error() + r.suffix
+}
+
+func (r *redacted) Unwrap() error {
+	return r.cause
+}
+
+func (r *redacted) Cause() error {
+	return r.cause
+}
+
+func (r *redacted) base() *redacted {
+	return r
+}
+
+// writeVerbose prints the innermost cause below the run of redacted layers
+// with its own %+v, then each redacted layer's text and stack outwards, in
+// the same order pkg/errors uses.
+func (r *redacted) writeVerbose(s fmt.State) {
+	var run []*redacted
+	var root error
+	Walk(r, func(e error) bool {
+		if rr, ok := e.(interface{ base() *redacted }); ok {
+			run = append(run, rr.base())
+			return true
+		}
+		root = e
+		return false
+	})
+	if root != nil {
+		fmt.Fprintf(s, "%+v", root)
+	}
+	for i := len(run) - 1; i >= 0; i-- {
+		if msg := trimLocal(run[i].prefix, run[i].suffix); msg != "" {
+			io.WriteString(s, "\n"+msg)
+		}
+		if st, ok := run[i].orig.(stackTracer); ok {
+			fmt.Fprintf(s, "%+v", st.StackTrace())
+		}
+	}
+}
+
+func (r *redacted) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			r.writeVerbose(s)
+			return
+		}
+		fallthrough
+	case 's':
+		io.WriteString(s, r.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", r.Error())
+	}
+}
@@ -0,0 +1,126 @@
+package common_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/mycodesmells/pkg-errors-example/common"
+	"github.com/mycodesmells/pkg-errors-example/wrap"
+	pkgerrors "github.com/pkg/errors"
+)
+
+func sensitiveCallC() common.MyError {
+	me := common.NewError(404, "user secret@example.com not found")
+	me.Sensitive = true
+	return me
+}
+
+func TestRedact(t *testing.T) {
+	orig := pkgerrors.Wrap(pkgerrors.Wrap(sensitiveCallC(), "Error from CallB"), "Error from CallA")
+	origText := fmt.Sprintf("%+v", orig)
+
+	got := common.Redact(orig)
+	if want := "Error from CallA: Error from CallB: Errrrr: [REDACTED]"; got.Error() != want {
+		t.Errorf("Error() = %q, want %q", got.Error(), want)
+	}
+	verbose := fmt.Sprintf("%+v", got)
+	if !strings.HasPrefix(verbose, "Errrrr: [REDACTED]\nError from CallB\n") ||
+		!strings.Contains(verbose, "\nError from CallA\n") ||
+		!strings.Contains(verbose, "common_test.TestRedact") {
+		t.Errorf("%%+v = %q, want redacted messages followed by the original stacks", verbose)
+	}
+	if strings.Contains(verbose, "secret") {
+		t.Errorf("%%+v leaks the sensitive message: %q", verbose)
+	}
+	if code, _ := common.CodeOf(got); code != 404 {
+		t.Errorf("CodeOf() = %d, want 404", code)
+	}
+
+	if after := fmt.Sprintf("%+v", orig); after != origText {
+		t.Errorf("Redact mutated the original:\n%s\nwant\n%s", after, origText)
+	}
+}
+
+func TestRedactPreservesStructure(t *testing.T) {
+	orig := wrap.WrapCode(pkgerrors.Wrap(sensitiveCallC(), "inner"), 500, "outer")
+	got := common.Redact(orig)
+
+	if codes := wrap.CodesInChain(got); fmt.Sprint(codes) != "[500 404]" {
+		t.Errorf("CodesInChain() = %v, want [500 404]", codes)
+	}
+	if cause, ok := pkgerrors.Cause(got).(common.MyError); !ok || cause.Msg != "[REDACTED]" {
+		t.Errorf("errors.Cause() = %#v, want the redacted MyError", pkgerrors.Cause(got))
+	}
+	var st interface{ StackTrace() pkgerrors.StackTrace }
+	if !errors.As(got, &st) || len(st.StackTrace()) == 0 {
+		t.Error("redacted chain lost its stack trace")
+	}
+}
+
+func TestRedactKeepsPointers(t *testing.T) {
+	me := sensitiveCallC()
+	got := common.Redact(pkgerrors.Wrap(&me, "outer"))
+	var ptr *common.MyError
+	if !errors.As(got, &ptr) || ptr.Msg != "[REDACTED]" {
+		t.Errorf("errors.As(*MyError) = %v, want the redacted pointer layer", ptr)
+	}
+	if me.Msg == "[REDACTED]" {
+		t.Error("Redact mutated the original *MyError")
+	}
+}
+
+func TestRedactNothingSensitive(t *testing.T) {
+	err := wrap.CallA()
+	if got := common.Redact(err); got != err {
+		t.Errorf("Redact() = %v, want the untouched error", got)
+	}
+}
+
+func TestRedactMulti(t *testing.T) {
+	other := errors.New("x")
+	var m common.MultiError
+	m.Add(pkgerrors.Wrap(sensitiveCallC(), "w"))
+	m.Add(other)
+	twoW := fmt.Errorf("a: %w; b: %w", sensitiveCallC(), other)
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"MultiError", m.ErrorOrNil(), "w: Errrrr: [REDACTED]\nx"},
+		{"Join", common.Join(sensitiveCallC(), other), "Errrrr: [REDACTED]\nx"},
+		{"fmt %w twice", twoW, "a: Errrrr: [REDACTED]; b: x"},
+		{"Errorf %w", common.Errorf("loading: %w", sensitiveCallC()), "Errrrr: loading: Errrrr: [REDACTED]"},
+		{"Errorf two %w", common.Errorf("loading: %w, %w", sensitiveCallC(), other), "Errrrr: loading: Errrrr: [REDACTED], x"},
+		{"nested", pkgerrors.Wrap(common.Join(other, common.Join(sensitiveCallC())), "top"), "top: x\nErrrrr: [REDACTED]"},
+	}
+	for _, tt := range tests {
+		got := common.Redact(tt.err)
+		if got.Error() != tt.want {
+			t.Errorf("%s: Error() = %q, want %q", tt.name, got.Error(), tt.want)
+		}
+		if verbose := fmt.Sprintf("%+v", got); strings.Contains(verbose, "secret") {
+			t.Errorf("%s: %%+v leaks the sensitive message: %q", tt.name, verbose)
+		}
+		if !errors.Is(got, common.WithCode(404)) {
+			t.Errorf("%s: the redacted MyError is no longer reachable", tt.name)
+		}
+	}
+
+	if got := common.Redact(&m); !errors.Is(got, other) {
+		t.Error("Redact(MultiError) dropped the non-sensitive child")
+	}
+	plain := common.Join(wrap.CallA(), other)
+	if got := common.Redact(plain); got != plain {
+		t.Errorf("Redact() = %v, want the untouched join", got)
+	}
+
+	cy := &common.MyError{Msg: "secret", Sensitive: true}
+	cy.Cause = common.Join(cy, cy)
+	if verbose := fmt.Sprintf("%+v", common.Redact(cy)); strings.Contains(verbose, "secret") {
+		t.Errorf("%%+v of a redacted join cycle leaks the sensitive message: %q", verbose)
+	}
+}
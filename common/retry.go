@@ -0,0 +1,38 @@
+package common
+
+func (me MyError) Temporary() bool {
+	return me.Retryable
+}
+
+func (me MyError) Timeout() bool {
+	return me.TimedOut
+}
+
+func IsRetryable(err error) bool {
+	retryable := false
+	Walk(err, func(e error) bool {
+		if t, ok := e.(interface{ Temporary() bool }); ok && t.Temporary() {
+			retryable = true
+		}
+		return !retryable
+	})
+	return retryable
+}
+
+func Retry(attempts int, fn func() error) error {
+	if attempts < 1 {
+		attempts = 1
+	}
+	var err error
+	n := 0
+	for n < attempts {
+		n++
+		if err = fn(); err == nil {
+			return nil
+		}
+		if !IsRetryable(err) {
+			break
+		}
+	}
+	return Errorw(err, "gave up after %d attempt(s)", n)
+}
@@ -0,0 +1,91 @@
+package common_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mycodesmells/pkg-errors-example/common"
+	"github.com/mycodesmells/pkg-errors-example/wrap"
+	pkgerrors "github.com/pkg/errors"
+)
+
+func TestIsRetryable(t *testing.T) {
+	callC := wrap.CallC().(common.MyError)
+	callC.Retryable = true
+	retryable := pkgerrors.Wrap(pkgerrors.Wrap(callC, "Error from CallB"), "Error from CallA")
+
+	if !common.IsRetryable(retryable) {
+		t.Error("IsRetryable() = false for a retryable CallC error, want true")
+	}
+	if common.IsRetryable(wrap.CallA()) {
+		t.Error("IsRetryable(wrap.CallA()) = true, want false")
+	}
+	if common.IsRetryable(nil) {
+		t.Error("IsRetryable(nil) = true, want false")
+	}
+}
+
+func TestTemporaryTimeout(t *testing.T) {
+	me := common.MyError{Retryable: true, TimedOut: true}
+	var err error = me
+	if tmp, ok := err.(interface{ Temporary() bool }); !ok || !tmp.Temporary() {
+		t.Error("MyError does not report Temporary()")
+	}
+	if to, ok := err.(interface{ Timeout() bool }); !ok || !to.Timeout() {
+		t.Error("MyError does not report Timeout()")
+	}
+}
+
+func TestRetry(t *testing.T) {
+	transient := common.MyError{Msg: "transient", Retryable: true}
+	fatal := common.MyError{Msg: "fatal"}
+
+	calls := 0
+	err := common.Retry(5, func() error {
+		calls++
+		if calls < 3 {
+			return transient
+		}
+		return nil
+	})
+	if err != nil || calls != 3 {
+		t.Errorf("Retry() = %v after %d calls, want nil after 3", err, calls)
+	}
+
+	calls = 0
+	err = common.Retry(5, func() error {
+		calls++
+		return fatal
+	})
+	if calls != 1 {
+		t.Errorf("Retry() made %d calls for a non-retryable error, want 1", calls)
+	}
+	if !errors.Is(err, fatal) {
+		t.Errorf("Retry() = %v, want it to wrap the fatal error", err)
+	}
+
+	calls = 0
+	err = common.Retry(3, func() error {
+		calls++
+		return transient
+	})
+	if calls != 3 {
+		t.Errorf("Retry() made %d calls, want 3", calls)
+	}
+	me, ok := err.(common.MyError)
+	if !ok || me.Msg != "gave up after 3 attempt(s)" {
+		t.Errorf("Retry() = %v, want \"gave up after 3 attempt(s)\"", err)
+	}
+	if cause := errors.Unwrap(err); cause != transient {
+		t.Errorf("errors.Unwrap() = %v, want the last attempt's error", cause)
+	}
+
+	calls = 0
+	common.Retry(0, func() error {
+		calls++
+		return transient
+	})
+	if calls != 1 {
+		t.Errorf("Retry(0) made %d calls, want 1", calls)
+	}
+}
@@ -0,0 +1,22 @@
+package common
+
+import "errors"
+
+var (
+	ErrNotFound = errors.New("not found")
+	ErrTimeout  = errors.New("timeout")
+	ErrInvalid  = errors.New("invalid")
+)
+
+func Wrap(sentinel error, msg string) MyError {
+	return created(MyError{Msg: msg, Cause: sentinel})
+}
+
+func IsAny(err error, targets ...error) bool {
+	for _, target := range targets {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package common_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mycodesmells/pkg-errors-example/common"
+	pkgerrors "github.com/pkg/errors"
+)
+
+func TestWrapSentinel(t *testing.T) {
+	callC := func() error { return common.Wrap(common.ErrNotFound, "Error from CallC") }
+	callB := func() error { return pkgerrors.Wrap(callC(), "Error from CallB") }
+	result := pkgerrors.Wrap(callB(), "Error from CallA")
+
+	if !errors.Is(result, common.ErrNotFound) {
+		t.Error("errors.Is(result, ErrNotFound) = false, want true")
+	}
+	if errors.Is(result, common.ErrTimeout) || errors.Is(result, common.ErrInvalid) {
+		t.Error("errors.Is matched an unrelated sentinel")
+	}
+	if !errors.Is(result, common.MyError{Msg: "Error from CallC"}) {
+		t.Error("errors.Is lost the MyError around the sentinel")
+	}
+}
+
+func TestIsAny(t *testing.T) {
+	err := pkgerrors.Wrap(common.Wrap(common.ErrTimeout, "Error from CallC"), "Error from CallB")
+	tests := []struct {
+		name    string
+		err     error
+		targets []error
+		want    bool
+	}{
+		{"match", err, []error{common.ErrNotFound, common.ErrTimeout}, true},
+		{"no match", err, []error{common.ErrNotFound, common.ErrInvalid}, false},
+		{"no targets", err, nil, false},
+		{"nil err", nil, []error{common.ErrTimeout}, false},
+		{"nil err and nil target", nil, []error{nil}, true},
+		{"nil target", err, []error{nil}, false},
+	}
+	for _, tt := range tests {
+		if got := common.IsAny(tt.err, tt.targets...); got != tt.want {
+			t.Errorf("%s: IsAny() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
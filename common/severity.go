@@ -0,0 +1,48 @@
+package common
+
+type Severity int
+
+// The zero Severity means the level was not set and is treated as
+// SeverityError.
+const (
+	SeverityDebug Severity = iota + 1
+	SeverityWarn
+	SeverityError
+	SeverityFatal
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityDebug:
+		return "debug"
+	case SeverityWarn:
+		return "warn"
+	case SeverityError:
+		return "error"
+	case SeverityFatal:
+		return "fatal"
+	}
+	return "unset"
+}
+
+func MaxSeverity(err error) Severity {
+	var max Severity
+	Walk(err, func(e error) bool {
+		me, ok := layer(e)
+		if !ok {
+			return true
+		}
+		level := me.Level
+		if level == 0 {
+			level = SeverityError
+		}
+		if level > max {
+			max = level
+		}
+		return true
+	})
+	if max == 0 {
+		return SeverityError
+	}
+	return max
+}
@@ -0,0 +1,32 @@
+package common_test
+
+import (
+	"testing"
+
+	"github.com/mycodesmells/pkg-errors-example/common"
+	"github.com/mycodesmells/pkg-errors-example/wrap"
+	pkgerrors "github.com/pkg/errors"
+)
+
+func TestMaxSeverity(t *testing.T) {
+	leveled := func(level common.Severity, cause error) common.MyError {
+		return common.MyError{Msg: level.String(), Cause: cause, Level: level}
+	}
+	tests := []struct {
+		name string
+		err  error
+		want common.Severity
+	}{
+		{"unset", wrap.CallA(), common.SeverityError},
+		{"nil", nil, common.SeverityError},
+		{"debug only", leveled(common.SeverityDebug, nil), common.SeverityDebug},
+		{"outer wins", leveled(common.SeverityFatal, leveled(common.SeverityWarn, nil)), common.SeverityFatal},
+		{"inner wins", pkgerrors.Wrap(leveled(common.SeverityWarn, leveled(common.SeverityFatal, nil)), "Error from CallA"), common.SeverityFatal},
+		{"unset counts as error", leveled(common.SeverityWarn, common.MyError{Msg: "unset"}), common.SeverityError},
+	}
+	for _, tt := range tests {
+		if got := common.MaxSeverity(tt.err); got != tt.want {
+			t.Errorf("%s: MaxSeverity() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package common
+
+import "log/slog"
+
+func (me MyError) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("message", me.Msg),
+		slog.Int("code", me.Code),
+	}
+	if fields := me.fieldMap(); len(fields) > 0 {
+		keys := sortedKeys(fields)
+		group := make([]slog.Attr, len(keys))
+		for i, k := range keys {
+			group[i] = slog.Any(k, fields[k])
+		}
+		attrs = append(attrs, slog.Attr{Key: "fields", Value: slog.GroupValue(group...)})
+	}
+	return slog.GroupValue(attrs...)
+}
+
+func LogValue(err error) slog.Value {
+	if me, ok := innermost(err); ok {
+		return me.LogValue()
+	}
+	if err == nil {
+		return slog.Value{}
+	}
+	return slog.StringValue(err.Error())
+}
@@ -0,0 +1,74 @@
+package common_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/mycodesmells/pkg-errors-example/bare"
+	"github.com/mycodesmells/pkg-errors-example/common"
+	"github.com/mycodesmells/pkg-errors-example/concat"
+	"github.com/mycodesmells/pkg-errors-example/wrap"
+)
+
+type recordHandler struct {
+	attrs map[string]slog.Value
+}
+
+func (h *recordHandler) Enabled(context.Context, slog.Level) bool { return true }
+func (h *recordHandler) WithAttrs([]slog.Attr) slog.Handler       { return h }
+func (h *recordHandler) WithGroup(string) slog.Handler            { return h }
+
+func (h *recordHandler) Handle(_ context.Context, r slog.Record) error {
+	r.Attrs(func(a slog.Attr) bool {
+		flatten(h.attrs, a.Key, a.Value.Resolve())
+		return true
+	})
+	return nil
+}
+
+func flatten(dst map[string]slog.Value, prefix string, v slog.Value) {
+	if v.Kind() != slog.KindGroup {
+		dst[prefix] = v
+		return
+	}
+	for _, a := range v.Group() {
+		flatten(dst, prefix+"."+a.Key, a.Value.Resolve())
+	}
+}
+
+func TestLogValue(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code int64
+	}{
+		{"wrap", wrap.CallA(), 404},
+		{"concat", concat.CallA(), 403},
+		{"bare", bare.CallAAnnotated(), 401},
+	}
+	for _, tt := range tests {
+		h := &recordHandler{attrs: map[string]slog.Value{}}
+		slog.New(h).Error("call failed", "err", common.LogValue(tt.err))
+
+		if got := h.attrs["err.message"].String(); got != "Error from CallC" {
+			t.Errorf("%s: err.message = %q, want Error from CallC", tt.name, got)
+		}
+		if got := h.attrs["err.code"].Int64(); got != tt.code {
+			t.Errorf("%s: err.code = %d, want %d", tt.name, got, tt.code)
+		}
+	}
+}
+
+func TestLogValueFields(t *testing.T) {
+	h := &recordHandler{attrs: map[string]slog.Value{}}
+	me := common.NewError(7, "boom").WithField("request", "r1")
+	slog.New(h).Error("failed", "err", me)
+
+	if got := h.attrs["err.fields.request"].String(); got != "r1" {
+		t.Errorf("err.fields.request = %q, want r1", got)
+	}
+	if got := h.attrs["err.code"].Int64(); got != 7 {
+		t.Errorf("err.code = %d, want 7", got)
+	}
+}
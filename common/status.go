@@ -0,0 +1,54 @@
+package common
+
+import (
+	"net/http"
+	"sync"
+)
+
+var (
+	statusMu sync.RWMutex
+	statuses = map[int]int{}
+)
+
+func RegisterStatus(code, httpStatus int) {
+	statusMu.Lock()
+	defer statusMu.Unlock()
+	statuses[code] = httpStatus
+}
+
+// HTTPStatus maps the code of the first MyError in the chain with a
+// recognized code to an HTTP status, so code-0 wrappers such as Retry or
+// Errorw are looked through. Registered codes win; otherwise codes that
+// already are valid HTTP statuses are used as is. Joined errors are searched
+// like CodeOf does, and anything else falls back to 500.
+func HTTPStatus(err error) int {
+	status, found := 0, false
+	Walk(err, func(e error) bool {
+		if me, ok := layer(e); ok {
+			status, found = httpStatus(me.Code)
+		}
+		return !found
+	})
+	if found {
+		return status
+	}
+	if me, ok := innermost(err); ok {
+		if status, ok := httpStatus(me.Code); ok {
+			return status
+		}
+	}
+	return http.StatusInternalServerError
+}
+
+func httpStatus(code int) (int, bool) {
+	statusMu.RLock()
+	status, ok := statuses[code]
+	statusMu.RUnlock()
+	if ok {
+		return status, true
+	}
+	if code >= 100 && code <= 599 {
+		return code, true
+	}
+	return 0, false
+}
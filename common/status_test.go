@@ -0,0 +1,33 @@
+package common_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/mycodesmells/pkg-errors-example/common"
+	"github.com/mycodesmells/pkg-errors-example/wrap"
+)
+
+func TestHTTPStatus(t *testing.T) {
+	common.RegisterStatus(9001, http.StatusTeapot)
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"registered", common.NewError(9001, "teapot"), http.StatusTeapot},
+		{"http code from wrap", wrap.CallA(), http.StatusNotFound},
+		{"unrecognized code", common.NewError(9002, "unknown"), http.StatusInternalServerError},
+		{"no MyError", errors.New("plain"), http.StatusInternalServerError},
+		{"code-0 wrapper", common.Wrap(wrap.CallA(), "outer"), http.StatusNotFound},
+		{"Retry", common.Retry(1, func() error { return common.NewError(404, "nf") }), http.StatusNotFound},
+		{"unrecognized outer", common.NewError(9002, "outer").WithCause(common.NewError(9001, "teapot")), http.StatusTeapot},
+		{"join", common.Join(errors.New("plain"), wrap.CallA()), http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		if got := common.HTTPStatus(tt.err); got != tt.want {
+			t.Errorf("%s: HTTPStatus() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package common
+
+import (
+	"strconv"
+	"strings"
+)
+
+// MarshalText encodes the error as "code:message", or just "message" when
+// the code is zero. If such a message would itself read as coded (it starts
+// with digits and a colon) it is written as "0:message" so it round-trips.
+func (me MyError) MarshalText() ([]byte, error) {
+	if me.Code != 0 {
+		return []byte(strconv.Itoa(me.Code) + ":" + me.Msg), nil
+	}
+	if _, _, ok := splitCode(me.Msg); ok {
+		return []byte("0:" + me.Msg), nil
+	}
+	return []byte(me.Msg), nil
+}
+
+func (me *MyError) UnmarshalText(text []byte) error {
+	s := string(text)
+	if code, msg, ok := splitCode(s); ok {
+		*me = MyError{Code: code, Msg: msg}
+		return nil
+	}
+	*me = MyError{Msg: s}
+	return nil
+}
+
+func splitCode(s string) (int, string, bool) {
+	i := strings.IndexByte(s, ':')
+	if i <= 0 {
+		return 0, "", false
+	}
+	code, err := strconv.Atoi(s[:i])
+	if err != nil {
+		return 0, "", false
+	}
+	return code, s[i+1:], true
+}
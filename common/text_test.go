@@ -0,0 +1,32 @@
+package common_test
+
+import (
+	"testing"
+
+	"github.com/mycodesmells/pkg-errors-example/common"
+)
+
+func TestTextRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		in   common.MyError
+		text string
+	}{
+		{"code", common.MyError{Code: 404, Msg: "Error from CallC"}, "404:Error from CallC"},
+		{"no code", common.MyError{Msg: "Error from CallC"}, "Error from CallC"},
+		{"colons", common.MyError{Msg: "CallC: lookup: failed"}, "CallC: lookup: failed"},
+		{"coded-looking message", common.MyError{Msg: "12:30 timeout"}, "0:12:30 timeout"},
+		{"code and colons", common.MyError{Code: 404, Msg: "12:30 timeout"}, "404:12:30 timeout"},
+	}
+	for _, tt := range tests {
+		text, err := tt.in.MarshalText()
+		if err != nil || string(text) != tt.text {
+			t.Errorf("%s: MarshalText() = %q, %v, want %q", tt.name, text, err, tt.text)
+			continue
+		}
+		var out common.MyError
+		if err := out.UnmarshalText(text); err != nil || out != tt.in {
+			t.Errorf("%s: UnmarshalText() = %#v, %v, want %#v", tt.name, out, err, tt.in)
+		}
+	}
+}
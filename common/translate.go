@@ -0,0 +1,43 @@
+package common
+
+import "sync/atomic"
+
+type translator func(key string, args ...interface{}) string
+
+var translate atomic.Value
+
+// SetTranslator installs the function used to render MyError values that
+// carry a MessageKey. Passing nil removes it, so Msg is used again.
+func SetTranslator(fn func(key string, args ...interface{}) string) {
+	translate.Store(translator(fn))
+}
+
+// argList is held by pointer for the same reason as fieldSet.
+type argList struct {
+	args []interface{}
+}
+
+// WithMessageKey returns a copy of me rendered through the translator with
+// key and args. Msg stays as the fallback text.
+func (me MyError) WithMessageKey(key string, args ...interface{}) MyError {
+	me.MessageKey = key
+	me.args = &argList{args: append([]interface{}(nil), args...)}
+	return me
+}
+
+func (me MyError) Args() []interface{} {
+	if me.args == nil {
+		return nil
+	}
+	return append([]interface{}(nil), me.args.args...)
+}
+
+func (me MyError) message() string {
+	if me.MessageKey == "" {
+		return me.Msg
+	}
+	if fn, _ := translate.Load().(translator); fn != nil {
+		return fn(me.MessageKey, me.Args()...)
+	}
+	return me.Msg
+}
@@ -0,0 +1,38 @@
+package common_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mycodesmells/pkg-errors-example/common"
+)
+
+func TestTranslator(t *testing.T) {
+	defer common.SetTranslator(nil)
+	me := common.NewError(404, "Error from CallC").WithMessageKey("not_found", "user", 7)
+
+	if got, want := me.Error(), "Errrrr: Error from CallC"; got != want {
+		t.Errorf("Error() without a translator = %q, want %q", got, want)
+	}
+
+	common.SetTranslator(func(key string, args ...interface{}) string {
+		return fmt.Sprintf("[%s] %v", key, args)
+	})
+	if got, want := me.Error(), "Errrrr: [not_found] [user 7]"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if got := common.NewError(404, "Error from CallC").Error(); got != "Errrrr: Error from CallC" {
+		t.Errorf("Error() without a MessageKey = %q, want Msg", got)
+	}
+
+	args := me.Args()
+	args[0] = "changed"
+	if me.Args()[0] != "user" {
+		t.Error("Args() exposes the error's own slice")
+	}
+
+	common.SetTranslator(nil)
+	if got, want := me.Error(), "Errrrr: Error from CallC"; got != want {
+		t.Errorf("Error() after SetTranslator(nil) = %q, want %q", got, want)
+	}
+}
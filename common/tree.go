@@ -0,0 +1,60 @@
+package common
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Tree renders the chain one layer per line, each indented under its parent.
+// Layers that add no text of their own, such as pkg/errors stack wrappers,
+// are skipped.
+func Tree(err error) string {
+	var b strings.Builder
+	Fprint(&b, err)
+	return b.String()
+}
+
+// Fprint writes the Tree rendering of err to w line by line and returns the
+// number of bytes written along with the first write error.
+func Fprint(w io.Writer, err error) (int, error) {
+	chain := Chain(err)
+	written := 0
+	depth := 0
+	for i, e := range chain {
+		var cause error
+		if i+1 < len(chain) {
+			cause = chain[i+1]
+		}
+		msg := local(e, cause)
+		if msg == "" {
+			continue
+		}
+		if me, ok := layer(e); ok {
+			msg += details(me)
+		}
+		n, werr := io.WriteString(w, strings.Repeat("  ", depth)+msg+"\n")
+		written += n
+		if werr != nil {
+			return written, werr
+		}
+		depth++
+	}
+	return written, nil
+}
+
+func details(me MyError) string {
+	var b strings.Builder
+	if me.Code != 0 {
+		fmt.Fprintf(&b, " [code=%d]", me.Code)
+	}
+	if fields := me.fieldMap(); len(fields) > 0 {
+		keys := sortedKeys(fields)
+		pairs := make([]string, len(keys))
+		for i, k := range keys {
+			pairs[i] = fmt.Sprintf("%s=%v", k, fields[k])
+		}
+		fmt.Fprintf(&b, " {%s}", strings.Join(pairs, ", "))
+	}
+	return b.String()
+}
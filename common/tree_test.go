@@ -0,0 +1,59 @@
+package common_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/mycodesmells/pkg-errors-example/bare"
+	"github.com/mycodesmells/pkg-errors-example/common"
+	"github.com/mycodesmells/pkg-errors-example/concat"
+	"github.com/mycodesmells/pkg-errors-example/wrap"
+)
+
+func TestTree(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"wrap", wrap.CallA(), "Error from CallA\n  Error from CallB\n    Error from CallC [code=404]\n"},
+		{"concat", concat.CallA(), "Error from CallA\n  Error from CallB\n    Error from CallC [code=403]\n"},
+		{"bare", bare.CallA(), "Error from CallC [code=401]\n"},
+		{"fields", common.NewError(404, "Error from CallC").WithField("user", 7).WithField("id", "x"), "Error from CallC [code=404] {id=x, user=7}\n"},
+		{"nil", nil, ""},
+	}
+	for _, tt := range tests {
+		if got := common.Tree(tt.err); got != tt.want {
+			t.Errorf("%s: Tree() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+type failingWriter struct {
+	n int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.n <= 0 {
+		return 0, errors.New("disk full")
+	}
+	w.n--
+	return len(p), nil
+}
+
+func TestFprint(t *testing.T) {
+	var buf bytes.Buffer
+	n, err := common.Fprint(&buf, wrap.CallA())
+	if err != nil || n != buf.Len() || buf.String() != common.Tree(wrap.CallA()) {
+		t.Errorf("Fprint() = %d, %v, wrote %q, want the Tree output", n, err, buf.String())
+	}
+
+	n, err = common.Fprint(&failingWriter{n: 1}, wrap.CallA())
+	if want := len("Error from CallA\n"); n != want {
+		t.Errorf("Fprint() to a failing writer = %d, want %d", n, want)
+	}
+	if err == nil || err.Error() != "disk full" {
+		t.Errorf("Fprint() error = %v, want the writer's error", err)
+	}
+}
@@ -0,0 +1,29 @@
+package common
+
+import (
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+var maxMessageLen atomic.Int64
+
+// SetMaxMessageLen limits MyError.Error to n runes of rendered text, followed
+// by "…" when anything was cut. Zero, the default, means no limit.
+func SetMaxMessageLen(n int) {
+	maxMessageLen.Store(int64(n))
+}
+
+func truncate(s string) string {
+	n := int(maxMessageLen.Load())
+	if n <= 0 || utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	runes := 0
+	for i := range s {
+		if runes == n {
+			return s[:i] + "…"
+		}
+		runes++
+	}
+	return s
+}
@@ -0,0 +1,36 @@
+package common_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mycodesmells/pkg-errors-example/common"
+)
+
+func TestSetMaxMessageLen(t *testing.T) {
+	defer common.SetMaxMessageLen(0)
+	long := common.MyError{Msg: strings.Repeat("x", 100)}
+
+	if got := long.Error(); got != "Errrrr: "+long.Msg {
+		t.Errorf("Error() with the default limit = %q, want the full message", got)
+	}
+
+	tests := []struct {
+		name string
+		max  int
+		msg  string
+		want string
+	}{
+		{"ascii", 12, long.Msg, "Errrrr: xxxx…"},
+		{"fits", 12, "xxxx", "Errrrr: xxxx"},
+		{"multibyte", 10, "żółć", "Errrrr: żó…"},
+		{"multibyte fits", 12, "żółć", "Errrrr: żółć"},
+		{"unlimited", 0, long.Msg, "Errrrr: " + long.Msg},
+	}
+	for _, tt := range tests {
+		common.SetMaxMessageLen(tt.max)
+		if got := (common.MyError{Msg: tt.msg}).Error(); got != tt.want {
+			t.Errorf("%s: Error() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -2,18 +2,87 @@ package concat
 
 import (
 	"fmt"
+	"strings"
+	"sync"
 
 	"github.com/mycodesmells/pkg-errors-example/common"
 )
 
 func CallA() error {
-	return fmt.Errorf("Error from CallA: %v", CallB())
+	return WrapNilSafe(CallB(), "Error from CallA")
 }
 
+// callC lets tests make CallB see a nil error from CallC.
+var callC = CallC
+
 func CallB() error {
-	return fmt.Errorf("Error from CallB: %v", CallC())
+	return WrapNilSafe(callC(), "Error from CallB")
 }
 
 func CallC() error {
-	return common.MyError{Msg: "Error from CallC"}
+	return common.NewError(403, "Error from CallC")
+}
+
+func WrapNilSafe(err error, msg string) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
+
+// prefixed is the allocation-light counterpart of fmt.Errorf("%s: %w"). The
+// full message is rendered once, on first use, into a pre-sized builder.
+type prefixed struct {
+	msg string
+	err error
+
+	once     sync.Once
+	rendered string
+}
+
+func (p *prefixed) Error() string {
+	p.once.Do(func() {
+		n := 0
+		var root error = p
+		for {
+			pp, ok := root.(*prefixed)
+			if !ok {
+				break
+			}
+			n += len(pp.msg) + 2
+			root = pp.err
+		}
+		rootMsg := root.Error()
+
+		var b strings.Builder
+		b.Grow(n + len(rootMsg))
+		for pp := p; pp != nil; {
+			b.WriteString(pp.msg)
+			b.WriteString(": ")
+			next, _ := pp.err.(*prefixed)
+			pp = next
+		}
+		b.WriteString(rootMsg)
+		p.rendered = b.String()
+	})
+	return p.rendered
+}
+
+func (p *prefixed) Unwrap() error {
+	return p.err
+}
+
+func CallAFast() error {
+	return prefix(CallBFast(), "Error from CallA")
+}
+
+func CallBFast() error {
+	return prefix(callC(), "Error from CallB")
+}
+
+func prefix(err error, msg string) error {
+	if err == nil {
+		return nil
+	}
+	return &prefixed{msg: msg, err: err}
 }
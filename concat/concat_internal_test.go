@@ -0,0 +1,15 @@
+package concat
+
+import "testing"
+
+func TestCallANil(t *testing.T) {
+	defer func(orig func() error) { callC = orig }(callC)
+	callC = func() error { return nil }
+
+	if err := CallA(); err != nil {
+		t.Errorf("CallA() = %v, want nil when CallC succeeds", err)
+	}
+	if err := CallAFast(); err != nil {
+		t.Errorf("CallAFast() = %v, want nil when CallC succeeds", err)
+	}
+}
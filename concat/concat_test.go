@@ -0,0 +1,56 @@
+package concat_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mycodesmells/pkg-errors-example/common"
+	"github.com/mycodesmells/pkg-errors-example/concat"
+)
+
+func TestCallAMessage(t *testing.T) {
+	if got, want := concat.CallA().Error(), "Error from CallA: Error from CallB: Errrrr: Error from CallC"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestCallAUnwrap(t *testing.T) {
+	err := concat.CallA()
+	if got, want := errors.Unwrap(err).Error(), concat.CallB().Error(); got != want {
+		t.Errorf("errors.Unwrap() = %q, want the CallB error %q", got, want)
+	}
+	var me common.MyError
+	if !errors.As(err, &me) || me.Msg != "Error from CallC" {
+		t.Errorf("errors.As() = %v, want the MyError from CallC", me)
+	}
+}
+
+func TestCallAFast(t *testing.T) {
+	fast := concat.CallAFast()
+	if got, want := fast.Error(), concat.CallA().Error(); got != want {
+		t.Errorf("CallAFast().Error() = %q, want %q", got, want)
+	}
+	if got, want := fast.Error(), concat.CallA().Error(); got != want {
+		t.Errorf("second CallAFast().Error() = %q, want %q", got, want)
+	}
+	if got, want := errors.Unwrap(fast).Error(), concat.CallB().Error(); got != want {
+		t.Errorf("errors.Unwrap(CallAFast()) = %q, want %q", got, want)
+	}
+	if !common.SameError(fast, concat.CallA()) {
+		t.Error("CallAFast() does not unwrap to the same MyError as CallA()")
+	}
+}
+
+func BenchmarkCallA(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = concat.CallA().Error()
+	}
+}
+
+func BenchmarkCallAFast(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = concat.CallAFast().Error()
+	}
+}
@@ -0,0 +1,27 @@
+package report
+
+import (
+	"fmt"
+
+	"github.com/mycodesmells/pkg-errors-example/common"
+)
+
+// Info summarizes what an error chain still carries. Layers is
+// len(common.Chain(err)), so it counts error values: wrap.CallA() has 5
+// because pkg/errors.Wrap adds a stack and a message layer per call.
+type Info struct {
+	Unwrappable     bool
+	Layers          int
+	RootRecoverable bool
+	Verbose         string
+}
+
+func Compare(err error) Info {
+	_, recoverable := common.AsMyError(err)
+	return Info{
+		Unwrappable:     common.Depth(err) > 0,
+		Layers:          len(common.Chain(err)),
+		RootRecoverable: recoverable,
+		Verbose:         fmt.Sprintf("%+v", err),
+	}
+}
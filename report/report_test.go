@@ -0,0 +1,37 @@
+package report_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mycodesmells/pkg-errors-example/bare"
+	"github.com/mycodesmells/pkg-errors-example/concat"
+	"github.com/mycodesmells/pkg-errors-example/report"
+	"github.com/mycodesmells/pkg-errors-example/wrap"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name string
+		info report.Info
+		want report.Info
+	}{
+		{"wrap", report.Compare(wrap.CallA()), report.Info{Unwrappable: true, Layers: 5, RootRecoverable: true}},
+		{"concat", report.Compare(concat.CallA()), report.Info{Unwrappable: true, Layers: 3, RootRecoverable: true}},
+		{"bare", report.Compare(bare.CallA()), report.Info{Unwrappable: false, Layers: 1, RootRecoverable: true}},
+	}
+	for _, tt := range tests {
+		got := tt.info
+		got.Verbose = ""
+		if got != tt.want {
+			t.Errorf("%s: Compare() = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+
+	if v := report.Compare(wrap.CallA()).Verbose; !strings.Contains(v, "wrap.CallA") {
+		t.Errorf("wrap Verbose = %q, want a stack trace", v)
+	}
+	if v := report.Compare(bare.CallA()).Verbose; v != "Errrrr: Error from CallC" {
+		t.Errorf("bare Verbose = %q, want the plain message", v)
+	}
+}
@@ -0,0 +1,22 @@
+package wrap
+
+import (
+	"path"
+	"runtime"
+)
+
+// Annotate wraps err with the name of the calling function, e.g.
+// "wrap.CallA", so the message cannot drift from the code. It returns nil
+// when err is nil.
+func Annotate(err error) error {
+	if err == nil {
+		return nil
+	}
+	name := "unknown"
+	if pc, _, _, ok := runtime.Caller(1); ok {
+		if fn := runtime.FuncForPC(pc); fn != nil {
+			name = path.Base(fn.Name())
+		}
+	}
+	return wrapper()(err, name)
+}
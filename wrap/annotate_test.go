@@ -0,0 +1,28 @@
+package wrap_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mycodesmells/pkg-errors-example/common"
+	"github.com/mycodesmells/pkg-errors-example/wrap"
+)
+
+func annotatedCallB() error {
+	return wrap.Annotate(wrap.CallC())
+}
+
+func TestAnnotate(t *testing.T) {
+	err := annotatedCallB()
+	if msg := err.Error(); !strings.HasPrefix(msg, "wrap_test.annotatedCallB: ") {
+		t.Errorf("Error() = %q, want it prefixed with the calling function", msg)
+	}
+	var me common.MyError
+	if !errors.As(err, &me) || me != wrap.CallC() {
+		t.Errorf("errors.As() = %v, want the CallC MyError", me)
+	}
+	if err := wrap.Annotate(nil); err != nil {
+		t.Errorf("Annotate(nil) = %v, want nil", err)
+	}
+}
@@ -0,0 +1,77 @@
+package wrap
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/mycodesmells/pkg-errors-example/common"
+)
+
+type coder interface {
+	Code() int
+}
+
+type withCode struct {
+	cause error
+	code  int
+}
+
+func WrapCode(err error, code int, msg string) error {
+	if err == nil {
+		return nil
+	}
+	return &withCode{
+		cause: wrapper()(err, msg),
+		code:  code,
+	}
+}
+
+func (w *withCode) Error() string {
+	return w.cause.Error()
+}
+
+func (w *withCode) Cause() error {
+	return w.cause
+}
+
+func (w *withCode) Unwrap() error {
+	return w.cause
+}
+
+func (w *withCode) Code() int {
+	return w.code
+}
+
+func (w *withCode) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			fmt.Fprintf(s, "%+v", w.cause)
+			return
+		}
+		fallthrough
+	case 's':
+		io.WriteString(s, w.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", w.Error())
+	}
+}
+
+func CodesInChain(err error) []int {
+	var codes []int
+	for _, e := range common.Chain(err) {
+		switch c := e.(type) {
+		case coder:
+			codes = append(codes, c.Code())
+		case common.MyError:
+			if c.Code != 0 {
+				codes = append(codes, c.Code)
+			}
+		case *common.MyError:
+			if c != nil && c.Code != 0 {
+				codes = append(codes, c.Code)
+			}
+		}
+	}
+	return codes
+}
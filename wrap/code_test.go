@@ -0,0 +1,54 @@
+package wrap_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/mycodesmells/pkg-errors-example/common"
+	"github.com/mycodesmells/pkg-errors-example/wrap"
+	pkgerrors "github.com/pkg/errors"
+)
+
+func TestCodesInChain(t *testing.T) {
+	err := wrap.WrapCode(wrap.WrapCode(wrap.CallC(), 502, "Error from CallB"), 500, "Error from CallA")
+	if got, want := wrap.CodesInChain(err), []int{500, 502, 404}; fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("CodesInChain() = %v, want %v", got, want)
+	}
+	if got, want := err.Error(), "Error from CallA: Error from CallB: Errrrr: Error from CallC"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if verbose := fmt.Sprintf("%+v", err); !strings.Contains(verbose, "wrap.WrapCode") {
+		t.Errorf("%%+v = %q, want a stack trace", verbose)
+	}
+	if !common.SameError(err, wrap.CallC()) {
+		t.Error("chain does not unwrap to the MyError from CallC")
+	}
+}
+
+func TestCodesInChainPointer(t *testing.T) {
+	var nilErr *common.MyError
+	tests := []struct {
+		name string
+		err  error
+		want []int
+	}{
+		{"pointer", pkgerrors.Wrap(&common.MyError{Code: 7}, "x"), []int{7}},
+		{"pointer under code", wrap.WrapCode(&common.MyError{Code: 7}, 500, "x"), []int{500, 7}},
+		{"nil pointer", pkgerrors.Wrap(nilErr, "x"), nil},
+	}
+	for _, tt := range tests {
+		if got := wrap.CodesInChain(tt.err); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("%s: CodesInChain() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestWrapCodeNil(t *testing.T) {
+	if err := wrap.WrapCode(nil, 500, "msg"); err != nil {
+		t.Errorf("WrapCode(nil) = %v, want nil", err)
+	}
+	if codes := wrap.CodesInChain(nil); codes != nil {
+		t.Errorf("CodesInChain(nil) = %v, want nil", codes)
+	}
+}
@@ -1,18 +1,33 @@
 package wrap
 
 import (
+	"sync/atomic"
+
 	"github.com/mycodesmells/pkg-errors-example/common"
 	"github.com/pkg/errors"
 )
 
+var noStack atomic.Bool
+
+func SetCaptureStack(capture bool) {
+	noStack.Store(!capture)
+}
+
+func wrapper() func(error, string) error {
+	if noStack.Load() {
+		return errors.WithMessage
+	}
+	return errors.Wrap
+}
+
 func CallA() error {
-	return errors.Wrap(CallB(), "Error from CallA")
+	return wrapper()(CallB(), "Error from CallA")
 }
 
 func CallB() error {
-	return errors.Wrap(CallC(), "Error from CallB")
+	return wrapper()(CallC(), "Error from CallB")
 }
 
 func CallC() error {
-	return common.MyError{Msg: "Error from CallC"}
+	return common.NewError(404, "Error from CallC")
 }
@@ -0,0 +1,59 @@
+package wrap_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mycodesmells/pkg-errors-example/common"
+	"github.com/mycodesmells/pkg-errors-example/wrap"
+	pkgerrors "github.com/pkg/errors"
+)
+
+type stackTracer interface {
+	StackTrace() pkgerrors.StackTrace
+}
+
+func TestCallAWithoutStack(t *testing.T) {
+	wrap.SetCaptureStack(false)
+	defer wrap.SetCaptureStack(true)
+
+	err := wrap.CallA()
+	if got, want := err.Error(), "Error from CallA: Error from CallB: Errrrr: Error from CallC"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	var st stackTracer
+	if errors.As(err, &st) {
+		t.Error("errors.As() found a stack trace with stacks disabled")
+	}
+	var me common.MyError
+	if !errors.As(err, &me) || me.Code != 404 {
+		t.Errorf("errors.As() = %v, want the 404 MyError from CallC", me)
+	}
+	if cause := pkgerrors.Cause(err); cause != wrap.CallC() {
+		t.Errorf("errors.Cause() = %v, want the CallC error", cause)
+	}
+}
+
+func TestCallAWithStack(t *testing.T) {
+	var st stackTracer
+	if !errors.As(wrap.CallA(), &st) || len(st.StackTrace()) == 0 {
+		t.Error("errors.As() found no stack trace with stacks enabled")
+	}
+}
+
+func BenchmarkCallA(b *testing.B) {
+	b.Run("stack", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = wrap.CallA()
+		}
+	})
+	b.Run("nostack", func(b *testing.B) {
+		wrap.SetCaptureStack(false)
+		defer wrap.SetCaptureStack(true)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = wrap.CallA()
+		}
+	})
+}